@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iamkrati22/kubechaos/targeting"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// defaultAbortInterval matches the polling interval of MonitorPodHealth
+const defaultAbortInterval = 5 * time.Second
+
+// AbortConditions stop an experiment early when its targets degrade too far
+type AbortConditions struct {
+	MaxRestarts     int  // Abort when the targets restarted more than this many times in total; negative disables
+	MinReadyPercent int  // Abort when fewer of the targets are Ready; 0 disables
+	OnPodFailure    bool // Abort when a target pod enters the Failed phase
+	OnProbeFailure  bool // Abort when a steady-state probe fails during injection
+	Interval        time.Duration
+}
+
+// AbortSpec is the file representation of the abort conditions
+type AbortSpec struct {
+	MaxRestarts     *int   `yaml:"maxRestarts"`
+	MinReadyPercent *int   `yaml:"minReadyPercent"`
+	OnPodFailure    *bool  `yaml:"onPodFailure"`
+	OnProbeFailure  *bool  `yaml:"onProbeFailure"`
+	Interval        string `yaml:"interval"`
+}
+
+// AbortError reports that an experiment was aborted and why
+type AbortError struct {
+	Reason string
+}
+
+func (e *AbortError) Error() string {
+	return "aborted: " + e.Reason
+}
+
+// watchesHealth reports whether any condition needs the target pods to be polled
+func (a AbortConditions) watchesHealth() bool {
+	return a.MaxRestarts >= 0 || a.MinReadyPercent > 0 || a.OnPodFailure
+}
+
+// healthWatcher polls the pods an experiment targets and evaluates the abort conditions
+type healthWatcher struct {
+	env        ChaosEnv
+	criteria   targeting.Criteria
+	conditions AbortConditions
+	baseline   map[types.UID]int32 // Restart counts when the experiment started
+}
+
+// newHealthWatcher records the restart counts of the targets before the fault is injected
+func newHealthWatcher(ctx context.Context, env ChaosEnv, config ChaosConfig, conditions AbortConditions) (*healthWatcher, error) {
+	namespaces, err := resolveTargetNamespaces(ctx, env.Clientset, config)
+	if err != nil {
+		return nil, err
+	}
+	criteria := targetCriteria(namespaces, config)
+	criteria.ReadyOnly = false
+	criteria.Phases = []v1.PodPhase{v1.PodPending, v1.PodRunning, v1.PodFailed}
+
+	watcher := &healthWatcher{env: env, criteria: criteria, conditions: conditions, baseline: map[types.UID]int32{}}
+	pods, err := watcher.pods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods {
+		watcher.baseline[pod.UID] = podRestarts(pod)
+	}
+	return watcher, nil
+}
+
+func (w *healthWatcher) pods(ctx context.Context) ([]v1.Pod, error) {
+	candidates, err := targeting.FindCandidates(ctx, w.env.Clientset, w.criteria)
+	if err != nil {
+		return nil, err
+	}
+	return candidates.Pods, nil
+}
+
+// check returns the reason to abort, or "" while the targets are healthy enough
+func (w *healthWatcher) check(ctx context.Context) string {
+	pods, err := w.pods(ctx)
+	if err != nil {
+		fmt.Printf("⚠️  Failed to check target health: %v\n", err)
+		return ""
+	}
+
+	restarts, ready := int32(0), 0
+	var failed []string
+	for _, pod := range pods {
+		restarts += podRestarts(pod) - w.baseline[pod.UID]
+		if pod.Status.Phase == v1.PodFailed {
+			failed = append(failed, pod.Namespace+"/"+pod.Name)
+		}
+		if targeting.IsPodReady(pod) {
+			ready++
+		}
+	}
+
+	if w.conditions.OnPodFailure && len(failed) > 0 {
+		return fmt.Sprintf("pods failed: %s", strings.Join(failed, ", "))
+	}
+	if w.conditions.MaxRestarts >= 0 && int(restarts) > w.conditions.MaxRestarts {
+		return fmt.Sprintf("%d container restarts, more than the allowed %d", restarts, w.conditions.MaxRestarts)
+	}
+	if w.conditions.MinReadyPercent > 0 {
+		percent := 0
+		if len(pods) > 0 {
+			percent = ready * 100 / len(pods)
+		}
+		if percent < w.conditions.MinReadyPercent {
+			return fmt.Sprintf("%d/%d target pods ready (%d%%), below %d%%", ready, len(pods), percent, w.conditions.MinReadyPercent)
+		}
+	}
+	return ""
+}
+
+// podRestarts sums the restart counts of the pod's containers
+func podRestarts(pod v1.Pod) int32 {
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
+
+// runGuarded injects the fault of an experiment once. The steady state is checked
+// first and nothing is injected when it does not hold. While the fault is active
+// the probes and the abort conditions are watched; when an abort condition is met
+// the injection is cancelled and the fault reverted. Afterwards the steady state
+// must recover. The returned error is an *AbortError when the experiment aborted.
+func runGuarded(ctx context.Context, env ChaosEnv, name string, injector ChaosInjector, config ChaosConfig, steadyState SteadyState, abort AbortConditions) error {
+	judged := len(steadyState.Probes) > 0
+	if judged {
+		fmt.Printf("🔬 Checking steady state before injecting %s...\n", name)
+		if failures := steadyState.check(ctx, env, ProbePhaseBefore); len(failures) > 0 {
+			fmt.Printf("🧪 Experiment %s: FAILED (steady state not met, nothing injected)\n", name)
+			return fmt.Errorf("steady state not met before injection: %s", describeProbeFailures(failures))
+		}
+		fmt.Println("✅ Steady state holds")
+	}
+
+	var watcher *healthWatcher
+	if abort.watchesHealth() && !config.DryRun {
+		var err error
+		if watcher, err = newHealthWatcher(ctx, env, config, abort); err != nil {
+			return fmt.Errorf("failed to record target health: %v", err)
+		}
+	}
+
+	// The run ID keeps the rollback of this injection away from the faults of other runs
+	config.RunID = newRunID()
+	injectCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	remove := activeFaults.add(name, injector, config)
+
+	var mu sync.Mutex
+	var abortReason string
+	duringFailures := map[string]error{}
+	triggerAbort := func(reason string) {
+		mu.Lock()
+		abortReason = reason
+		mu.Unlock()
+
+		fmt.Printf("🛑 Aborting %s: %s\n", name, reason)
+		cancel()
+		// Reverting stops the stress processes and helper pods the injection is waiting on
+		revertCtx, cancelRevert := context.WithTimeout(context.Background(), env.RevertTimeout)
+		defer cancelRevert()
+		if err := injector.Revert(revertCtx, env, config); err != nil {
+			fmt.Printf("⚠️  Rollback of %s incomplete: %v\n", name, err)
+		}
+	}
+
+	// Keep probing and watching the targets while the fault is active
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var probeTick, healthTick <-chan time.Time
+		if judged {
+			ticker := time.NewTicker(steadyState.Interval)
+			defer ticker.Stop()
+			probeTick = ticker.C
+		}
+		if watcher != nil {
+			ticker := time.NewTicker(abort.Interval)
+			defer ticker.Stop()
+			healthTick = ticker.C
+		}
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-probeTick:
+				failures := steadyState.check(injectCtx, env, ProbePhaseDuring)
+				mu.Lock()
+				for probe, err := range failures {
+					if _, seen := duringFailures[probe]; !seen {
+						duringFailures[probe] = err
+					}
+				}
+				mu.Unlock()
+				if abort.OnProbeFailure && len(failures) > 0 {
+					triggerAbort("probe failed: " + describeProbeFailures(failures))
+					return
+				}
+			case <-healthTick:
+				if reason := watcher.check(injectCtx); reason != "" {
+					triggerAbort(reason)
+					return
+				}
+			}
+		}
+	}()
+
+	injectErr := injector.Inject(injectCtx, env, config)
+	close(stop)
+	<-done
+
+	// On shutdown the fault stays tracked, so the shutdown path reverts it
+	if ctx.Err() != nil && abortReason == "" {
+		fmt.Printf("🛑 %s interrupted\n", name)
+		return ctx.Err()
+	}
+	remove()
+
+	var afterFailures map[string]error
+	if judged {
+		fmt.Printf("🔬 Waiting up to %s for the steady state to recover...\n", steadyState.RecoveryTimeout)
+		deadline := time.Now().Add(steadyState.RecoveryTimeout)
+		afterFailures = steadyState.check(ctx, env, ProbePhaseAfter)
+		for len(afterFailures) > 0 && time.Now().Add(steadyState.Interval).Before(deadline) {
+			if holdChaos(ctx, steadyState.Interval) != nil {
+				return ctx.Err()
+			}
+			afterFailures = steadyState.check(ctx, env, ProbePhaseAfter)
+		}
+	}
+
+	if abortReason != "" {
+		fmt.Printf("🧪 Experiment %s: ABORTED (%s)\n", name, abortReason)
+		return &AbortError{Reason: abortReason}
+	}
+
+	var reasons []string
+	if injectErr != nil {
+		reasons = append(reasons, injectErr.Error())
+	}
+	if len(duringFailures) > 0 {
+		reasons = append(reasons, "during injection: "+describeProbeFailures(duringFailures))
+	}
+	if len(afterFailures) > 0 {
+		reasons = append(reasons, "not recovered: "+describeProbeFailures(afterFailures))
+	}
+	if len(reasons) > 0 {
+		if judged {
+			fmt.Printf("🧪 Experiment %s: FAILED\n", name)
+		}
+		return errors.New(strings.Join(reasons, "; "))
+	}
+	if judged {
+		fmt.Printf("🧪 Experiment %s: PASSED\n", name)
+	}
+	return nil
+}
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"time"
 	"os"
@@ -10,7 +14,11 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"github.com/iamkrati22/kubechaos/targeting"
 	"github.com/robfig/cron/v3"
 	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/client-go/rest"
@@ -24,12 +32,29 @@ const (
 	ChaosTypeCPUStress ChaosType = "cpu-stress"
 	ChaosTypeMemoryStress ChaosType = "memory-stress"
 	ChaosTypeNetworkLatency ChaosType = "network-latency"
+	ChaosTypeNetworkLoss ChaosType = "network-loss"
+	ChaosTypeNetworkCorrupt ChaosType = "network-corrupt"
+	ChaosTypeNetworkDuplicate ChaosType = "network-duplicate"
+	ChaosTypeNetworkReorder ChaosType = "network-reorder"
+	ChaosTypeNetworkBandwidth ChaosType = "network-bandwidth"
+	ChaosTypeNetworkPartition ChaosType = "network-partition"
+	ChaosTypeDNSFailure ChaosType = "dns-failure"
 	ChaosTypeCronTrigger ChaosType = "cron-trigger"
 	ChaosTypeInPodCPUStress ChaosType = "in-pod-cpu-stress"
 	ChaosTypeInPodMemoryStress ChaosType = "in-pod-memory-stress"
 	ChaosTypeInPodMixedStress ChaosType = "in-pod-mixed-stress"
+	ChaosTypeInPodIOStress ChaosType = "in-pod-io-stress"
+	ChaosTypeDiskFill ChaosType = "disk-fill"
+	ChaosTypeTimeSkew ChaosType = "time-skew"
 	ChaosTypeKillProcess ChaosType = "kill-process"
+	ChaosTypePodFreeze ChaosType = "pod-freeze"
+	ChaosTypeContainerRestart ChaosType = "container-restart"
+	ChaosTypeScaleToZero ChaosType = "scale-to-zero"
+	ChaosTypeScaleDown ChaosType = "scale-down"
 	ChaosTypeCorruptMemory ChaosType = "corrupt-memory"
+	ChaosTypeNodeCordon ChaosType = "node-cordon"
+	ChaosTypeNodeDrain ChaosType = "node-drain"
+	ChaosTypeNodeTaint ChaosType = "node-taint"
 )
 
 // StressCommandType represents different types of stress commands
@@ -44,12 +69,92 @@ const (
 
 // ChaosConfig holds configuration for chaos operations
 type ChaosConfig struct {
-	Type        ChaosType
-	Namespace   string
-	Labels      map[string]string
-	Duration    time.Duration
-	Intensity   int // 1-10 scale
-	TargetCount int
+	Type               ChaosType
+	Namespace          string // Used when NamespaceSelection is empty
+	NamespaceSelection targeting.NamespaceCriteria
+	LabelSelector      labels.Selector // nil selects every pod
+	FieldSelector      string
+	ReadyOnly          bool // Only target pods that are Ready
+	Duration           time.Duration
+	Intensity          int // 1-10 scale
+	TargetCount        int
+	TargetPercent      int                 // Percentage of the candidates to target; overrides TargetCount when set
+	TargetMin          int                 // Lower bound of the percentage-based target count
+	TargetMax          int                 // Upper bound of the percentage-based target count; 0 leaves it open
+	Selection          targeting.Selection // How victims are picked among the candidates
+	DryRun             bool
+	Container          string              // Container restarted by container-restart; empty picks the first one
+	Strict             bool                // Only target pods annotated with kubechaos.io/enabled=true
+	IgnorePDB          bool                // Delete pods directly instead of evicting them
+	MinAvailable       *intstr.IntOrString // Ready pods to keep per owner (N or X%); nil disables
+	Network            NetworkChaosConfig
+	Node               NodeChaosConfig
+	DNS                DNSChaosConfig
+	Disk               DiskChaosConfig
+	Time               TimeChaosConfig
+	Process            ProcessChaosConfig
+	Workload           WorkloadChaosConfig
+	Rand               *rand.Rand // Source of every random choice of the run, seeded from -seed
+	RunID              string     // Set for every injection; scopes its revert to what it created
+}
+
+// NetworkChaosConfig holds specific configuration for network chaos
+type NetworkChaosConfig struct {
+	Interface string        // Network interface inside the pod (e.g., "eth0")
+	Latency   time.Duration // Delay added to egress packets
+	Jitter    time.Duration // Random variation of the delay
+	Loss      float64       // Percentage of egress packets dropped (network-loss)
+	Corrupt   float64       // Percentage of egress packets corrupted (network-corrupt)
+	Duplicate float64       // Percentage of egress packets duplicated (network-duplicate)
+	Reorder   float64       // Percentage of egress packets sent ahead of the delayed ones (network-reorder)
+	Rate      string        // Egress rate limit in tc syntax, e.g. "1mbit" (network-bandwidth)
+
+	// Destinations and Ports scope the fault to traffic to these CIDRs and/or
+	// destination ports; when both are empty all egress traffic is affected
+	Destinations []string
+	Ports        []int
+
+	// PeerSelector selects the pods cut off from the targets by network-partition
+	PeerSelector labels.Selector
+}
+
+// DNSChaosConfig holds specific configuration for DNS chaos
+type DNSChaosConfig struct {
+	Mode    string   // fail, timeout or wrong
+	Domains []string // Domains to break, with subdomains except in wrong mode; empty breaks every lookup (not with wrong)
+	Answer  string   // IP address the domains resolve to in wrong mode
+}
+
+// DiskChaosConfig holds specific configuration for disk-fill chaos
+type DiskChaosConfig struct {
+	Path        string // Mount path to fill inside the container (emptyDir, PVC or root filesystem)
+	FillPercent int    // Filesystem usage to reach, 1-100
+}
+
+// TimeChaosConfig holds specific configuration for time-skew chaos
+type TimeChaosConfig struct {
+	Offset      time.Duration // Shift of the wall clock; negative values move it back
+	HelperImage string        // Image of the ephemeral container providing watchmaker
+}
+
+// ProcessChaosConfig selects the processes of the process chaos types
+type ProcessChaosConfig struct {
+	Match        string        // Extended regex matched against process command lines; empty selects every process
+	Signal       string        // Signal name sent by kill-process, without the SIG prefix (e.g. "TERM")
+	AllowPID1    bool          // Whether kill-process may signal the container's PID 1
+	Interval     time.Duration // Repeat kill-process at this interval for the duration; 0 signals once
+	MaxProcesses int           // Most processes kill-process signals per pod and round
+}
+
+// WorkloadChaosConfig holds specific configuration for the workload scale chaos types
+type WorkloadChaosConfig struct {
+	ScalePercent int // Percentage of the replicas scale-down removes, rounded up (1-100)
+}
+
+// NodeChaosConfig selects the nodes of node-scoped chaos types
+type NodeChaosConfig struct {
+	LabelSelector labels.Selector // nil selects every node
+	Zones         []string        // topology.kubernetes.io/zone values; empty accepts any
 }
 
 // CPUStressConfig holds specific configuration for CPU stress testing
@@ -65,6 +170,77 @@ type CronTriggerConfig struct {
 	ChaosType    ChaosType
 	Probability  float64 // Probability of triggering (0.0-1.0)
 	MaxDuration  time.Duration
+	Template     ChaosConfig // Settings copied into every triggered run
+	Randomize    bool        // Pick a random intensity and target count for every triggered run
+	Safety       SafetyLimits
+	SteadyState  SteadyState // Probes that judge every triggered run
+	Abort        AbortConditions
+}
+
+func init() {
+	RegisterInjector(&funcInjector{
+		name:   ChaosTypeCPUStress,
+		inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return ApplyCPUStress(ctx, env.Clientset, config)
+		},
+		revert: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return forEachTargetNamespace(ctx, env.Clientset, config, func(namespace string) error {
+				return deleteStressPods(ctx, env.Clientset, namespace, ChaosTypeCPUStress, config.RunID)
+			})
+		},
+	}, "Run CPU stress in helper pods")
+	RegisterInjector(&funcInjector{
+		name:   ChaosTypeMemoryStress,
+		inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return ApplyMemoryStress(ctx, env.Clientset, config)
+		},
+		revert: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return forEachTargetNamespace(ctx, env.Clientset, config, func(namespace string) error {
+				return deleteStressPods(ctx, env.Clientset, namespace, ChaosTypeMemoryStress, config.RunID)
+			})
+		},
+	}, "Run memory stress in helper pods")
+	RegisterInjector(&funcInjector{
+		name: ChaosTypeInPodCPUStress,
+		inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return ApplyInPodCPUStress(ctx, env.RestConfig, env.Clientset, config)
+		},
+		revert: revertStressProcesses,
+	}, "CPU stress inside the target containers")
+	RegisterInjector(&funcInjector{
+		name: ChaosTypeInPodMemoryStress,
+		inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return ApplyInPodMemoryStress(ctx, env.RestConfig, env.Clientset, config)
+		},
+		revert: revertStressProcesses,
+	}, "Memory stress inside the target containers")
+	RegisterInjector(&funcInjector{
+		name: ChaosTypeInPodMixedStress,
+		inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return ApplyInPodMixedStress(ctx, env.RestConfig, env.Clientset, config)
+		},
+		revert: revertStressProcesses,
+	}, "Combined CPU, memory and I/O stress inside the target containers")
+	RegisterInjector(&funcInjector{
+		name: ChaosTypeInPodIOStress,
+		inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return ApplyInPodIOStress(ctx, env.RestConfig, env.Clientset, config)
+		},
+		revert: revertStressProcesses,
+	}, "Disk I/O stress inside the target containers")
+	RegisterInjector(&funcInjector{
+		name:     ChaosTypeKillProcess,
+		validate: validateKillProcess,
+		inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return ApplyKillProcessChaos(ctx, env.RestConfig, env.Clientset, config)
+		},
+	}, "Signal processes in the target containers, selected by name/regex")
+	RegisterInjector(&funcInjector{
+		name: ChaosTypeCorruptMemory,
+		inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return ApplyCorruptMemoryChaos(ctx, env.RestConfig, env.Clientset, config)
+		},
+	}, "Attempt memory corruption in the target containers")
 }
 
 // generateStressCommand creates a stress command based on type and intensity
@@ -100,11 +276,12 @@ func generateStressCommand(stressType StressCommandType, intensity int, duration
 		stressCmd += fmt.Sprintf("timeout %s bash -c 'for i in {1..%d}; do dd if=/dev/zero of=/dev/null bs=1M count=%d & done; wait'", 
 			duration.String(), intensity, intensity*25)
 	case StressCommandIO:
-		stressCmd += fmt.Sprintf("stress-ng --io %d --hdd %d --hdd-bytes %dM --timeout %s", 
-			intensity, intensity/2, intensity*25, duration.String())
+		// Both variants write to /tmp, the working directory may be read-only
+		stressCmd += fmt.Sprintf("stress-ng --io %d --hdd %d --hdd-bytes %dM --temp-path /tmp --timeout %s", 
+			intensity, (intensity+1)/2, intensity*25, duration.String())
 		stressCmd += "; else "
-		stressCmd += fmt.Sprintf("timeout %s bash -c 'for i in {1..%d}; do dd if=/dev/zero of=/dev/null bs=1M & done; wait'", 
-			duration.String(), intensity)
+		stressCmd += fmt.Sprintf("sh -c 'end=$(( $(date +%%s) + %d )); for i in $(seq %d); do (while [ $(date +%%s) -lt $end ]; do dd if=/dev/zero of=/tmp/.kubechaos-io-$i bs=1M count=%d conv=fsync 2>/dev/null; done) & done; wait'; rm -f /tmp/.kubechaos-io-*", 
+			int(duration.Seconds()), intensity, intensity*25)
 	case StressCommandMixed:
 		stressCmd += fmt.Sprintf("stress-ng --cpu %d --vm %d --vm-bytes %dM --io %d --timeout %s", 
 			intensity, intensity/2, intensity*25, intensity/2, duration.String())
@@ -124,45 +301,26 @@ func generateStressCommand(stressType StressCommandType, intensity int, duration
 }
 
 // ApplyCPUStress applies CPU stress to selected pods
-func ApplyCPUStress(clientset *kubernetes.Clientset, config ChaosConfig) error {
-	fmt.Printf("🔥 Applying CPU stress chaos to namespace: %s\n", config.Namespace)
+func ApplyCPUStress(ctx context.Context, clientset *kubernetes.Clientset, config ChaosConfig) error {
+	fmt.Printf("🔥 Applying CPU stress chaos to namespace: %s\n", config.namespaceScope())
 	
-	// List pods based on labels
-	listOptions := metav1.ListOptions{}
-	if len(config.Labels) > 0 {
-		labelSelector := ""
-		for k, v := range config.Labels {
-			if labelSelector != "" {
-				labelSelector += ","
-			}
-			labelSelector += fmt.Sprintf("%s=%s", k, v)
-		}
-		listOptions.LabelSelector = labelSelector
-	}
-
-	pods, err := clientset.CoreV1().Pods(config.Namespace).List(context.TODO(), listOptions)
+	selectedPods, err := selectTargets(ctx, clientset, config)
 	if err != nil {
-		return fmt.Errorf("failed to list pods: %v", err)
-	}
-
-	if len(pods.Items) == 0 {
-		return fmt.Errorf("no pods found in namespace %s", config.Namespace)
+		return err
 	}
-
-	// Select random pods to stress
-	podsToStress := config.TargetCount
-	if podsToStress > len(pods.Items) {
-		podsToStress = len(pods.Items)
-		fmt.Printf("⚠️  Requested to stress %d pods but only %d are available\n", config.TargetCount, len(pods.Items))
+	if config.DryRun {
+		printDryRun("No stress pods will be created", "start a CPU stress pod next to", selectedPods, cpuStressPodCommand(config))
+		return nil
 	}
-
-	selectedPods := selectRandomPods(pods.Items, podsToStress)
 	
 	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		fmt.Printf("🔥 Stressing pod %d/%d: %s\n", i+1, len(selectedPods), pod.Name)
 		
 		// Create a stress container in the pod
-		err := createStressContainer(clientset, config.Namespace, pod.Name, config)
+		err := createStressContainer(ctx, clientset, pod.Namespace, pod.Name, config)
 		if err != nil {
 			fmt.Printf("❌ Failed to stress pod %s: %v\n", pod.Name, err)
 		} else {
@@ -170,11 +328,35 @@ func ApplyCPUStress(clientset *kubernetes.Clientset, config ChaosConfig) error {
 		}
 	}
 
-	return nil
+	// Keep the run going while the helper pods work, so it can be aborted
+	fmt.Printf("⏳ Stress pods run for %s...\n", config.Duration)
+	return holdChaos(ctx, config.Duration)
+}
+
+// holdChaos waits until an injected fault has been active for its duration, or ctx is cancelled
+func holdChaos(ctx context.Context, duration time.Duration) error {
+	select {
+	case <-time.After(duration):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// cpuStressPodCommand is the shell command run by a CPU stress helper pod
+func cpuStressPodCommand(config ChaosConfig) string {
+	return fmt.Sprintf("apk add --no-cache stress-ng && stress-ng --cpu %d --timeout %s",
+		config.Intensity*10, config.Duration.String())
+}
+
+// memoryStressPodCommand is the shell command run by a memory stress helper pod
+func memoryStressPodCommand(config ChaosConfig) string {
+	return fmt.Sprintf("apk add --no-cache stress-ng && stress-ng --vm %d --vm-bytes %dM --timeout %s",
+		config.Intensity, config.Intensity*50, config.Duration.String())
 }
 
 // createStressContainer creates a stress container in the target pod
-func createStressContainer(clientset *kubernetes.Clientset, namespace, podName string, config ChaosConfig) error {
+func createStressContainer(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName string, config ChaosConfig) error {
 	// For now, we'll simulate the stress by creating a temporary job
 	// In a real implementation, you might want to use kubectl exec or create a sidecar container
 	
@@ -186,6 +368,7 @@ func createStressContainer(clientset *kubernetes.Clientset, namespace, podName s
 			Labels: map[string]string{
 				"chaos-type": "cpu-stress",
 				"target-pod": podName,
+				runIDLabel:   config.RunID,
 			},
 		},
 		Spec: v1.PodSpec{
@@ -193,11 +376,7 @@ func createStressContainer(clientset *kubernetes.Clientset, namespace, podName s
 				{
 					Name:  "stress",
 					Image: "alpine:latest",
-					Command: []string{
-						"sh", "-c",
-						fmt.Sprintf("apk add --no-cache stress-ng && stress-ng --cpu %d --timeout %s",
-							config.Intensity*10, config.Duration.String()),
-					},
+					Command: []string{"sh", "-c", cpuStressPodCommand(config)},
 					Resources: v1.ResourceRequirements{
 						Requests: v1.ResourceList{
 							v1.ResourceCPU:    resource.MustParse("100m"),
@@ -214,39 +393,27 @@ func createStressContainer(clientset *kubernetes.Clientset, namespace, podName s
 		},
 	}
 
-	_, err := clientset.CoreV1().Pods(namespace).Create(context.TODO(), job, metav1.CreateOptions{})
+	_, err := clientset.CoreV1().Pods(namespace).Create(ctx, job, metav1.CreateOptions{})
 	return err
 }
 
 // ApplyMemoryStress applies memory stress to selected pods
-func ApplyMemoryStress(clientset *kubernetes.Clientset, config ChaosConfig) error {
-	fmt.Printf("💾 Applying memory stress chaos to namespace: %s\n", config.Namespace)
+func ApplyMemoryStress(ctx context.Context, clientset *kubernetes.Clientset, config ChaosConfig) error {
+	fmt.Printf("💾 Applying memory stress chaos to namespace: %s\n", config.namespaceScope())
 	
-	// Similar to CPU stress but targets memory
-	listOptions := metav1.ListOptions{}
-	if len(config.Labels) > 0 {
-		labelSelector := ""
-		for k, v := range config.Labels {
-			if labelSelector != "" {
-				labelSelector += ","
-			}
-			labelSelector += fmt.Sprintf("%s=%s", k, v)
-		}
-		listOptions.LabelSelector = labelSelector
-	}
-
-	pods, err := clientset.CoreV1().Pods(config.Namespace).List(context.TODO(), listOptions)
+	selectedPods, err := selectTargets(ctx, clientset, config)
 	if err != nil {
-		return fmt.Errorf("failed to list pods: %v", err)
+		return err
 	}
-
-	if len(pods.Items) == 0 {
-		return fmt.Errorf("no pods found in namespace %s", config.Namespace)
+	if config.DryRun {
+		printDryRun("No stress pods will be created", "start a memory stress pod next to", selectedPods, memoryStressPodCommand(config))
+		return nil
 	}
-
-	selectedPods := selectRandomPods(pods.Items, config.TargetCount)
 	
 	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		fmt.Printf("💾 Stressing memory for pod %d/%d: %s\n", i+1, len(selectedPods), pod.Name)
 		
 		// Create memory stress job
@@ -254,10 +421,11 @@ func ApplyMemoryStress(clientset *kubernetes.Clientset, config ChaosConfig) erro
 		job := &v1.Pod{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      jobName,
-				Namespace: config.Namespace,
+				Namespace: pod.Namespace,
 				Labels: map[string]string{
 					"chaos-type": "memory-stress",
 					"target-pod": pod.Name,
+					runIDLabel:   config.RunID,
 				},
 			},
 			Spec: v1.PodSpec{
@@ -265,11 +433,7 @@ func ApplyMemoryStress(clientset *kubernetes.Clientset, config ChaosConfig) erro
 					{
 						Name:  "memstress",
 						Image: "alpine:latest",
-						Command: []string{
-							"sh", "-c",
-							fmt.Sprintf("apk add --no-cache stress-ng && stress-ng --vm %d --vm-bytes %dM --timeout %s",
-								config.Intensity, config.Intensity*50, config.Duration.String()),
-						},
+						Command: []string{"sh", "-c", memoryStressPodCommand(config)},
 						Resources: v1.ResourceRequirements{
 							Requests: v1.ResourceList{
 								v1.ResourceCPU:    resource.MustParse("100m"),
@@ -286,7 +450,7 @@ func ApplyMemoryStress(clientset *kubernetes.Clientset, config ChaosConfig) erro
 			},
 		}
 
-		_, err := clientset.CoreV1().Pods(config.Namespace).Create(context.TODO(), job, metav1.CreateOptions{})
+		_, err := clientset.CoreV1().Pods(pod.Namespace).Create(ctx, job, metav1.CreateOptions{})
 		if err != nil {
 			fmt.Printf("❌ Failed to stress memory for pod %s: %v\n", pod.Name, err)
 		} else {
@@ -294,11 +458,13 @@ func ApplyMemoryStress(clientset *kubernetes.Clientset, config ChaosConfig) erro
 		}
 	}
 
-	return nil
+	// Keep the run going while the helper pods work, so it can be aborted
+	fmt.Printf("⏳ Stress pods run for %s...\n", config.Duration)
+	return holdChaos(ctx, config.Duration)
 }
 
 // StartCronTrigger starts a cron-based chaos trigger
-func StartCronTrigger(clientset *kubernetes.Clientset, config CronTriggerConfig) {
+func StartCronTrigger(ctx context.Context, env ChaosEnv, config CronTriggerConfig) {
 	fmt.Printf("⏰ Starting cron chaos trigger with schedule: %s\n", config.Schedule)
 	
 	// Parse cron schedule
@@ -308,35 +474,57 @@ func StartCronTrigger(clientset *kubernetes.Clientset, config CronTriggerConfig)
 		return
 	}
 
+	injector, err := LookupInjector(config.ChaosType)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	// Randomized parameters stay within the safety limits
+	maxIntensity, maxTargets := 10, 3
+	if config.Safety.MaxIntensity > 0 && config.Safety.MaxIntensity < maxIntensity {
+		maxIntensity = config.Safety.MaxIntensity
+	}
+	if config.Safety.MaxTargets > 0 && config.Safety.MaxTargets < maxTargets {
+		maxTargets = config.Safety.MaxTargets
+	}
+
+	// Probability rolls and randomized parameters share the run's seeded source
+	rng := config.Template.random()
+
 	// Start the cron trigger in a goroutine
 	go func() {
 		for {
 			next := schedule.Next(time.Now())
-			time.Sleep(time.Until(next))
+			if holdChaos(ctx, time.Until(next)) != nil {
+				fmt.Println("⏰ Cron trigger stopped")
+				return
+			}
 			
 			// Check probability
-			if rand.Float64() <= config.Probability {
+			if rng.Float64() <= config.Probability {
 				fmt.Printf("🎲 Cron trigger fired! Applying chaos type: %s\n", config.ChaosType)
 				
 				// Apply the configured chaos type
-				chaosConfig := ChaosConfig{
-					Type:        config.ChaosType,
-					Namespace:   "default", // You might want to make this configurable
-					Duration:    config.MaxDuration,
-					Intensity:   rand.Intn(10) + 1, // Random intensity 1-10
-					TargetCount: rand.Intn(3) + 1,  // Random target count 1-3
+				chaosConfig := config.Template
+				chaosConfig.Type = config.ChaosType
+				chaosConfig.Duration = config.MaxDuration
+				if config.Randomize {
+					chaosConfig.Intensity = rng.Intn(maxIntensity) + 1
+					chaosConfig.TargetCount = rng.Intn(maxTargets) + 1
 				}
 				
-				switch config.ChaosType {
-				case ChaosTypePodDelete:
-					// This would call the existing pod deletion logic
-					fmt.Printf("💀 Cron triggered pod deletion\n")
-				case ChaosTypeCPUStress:
-					ApplyCPUStress(clientset, chaosConfig)
-				case ChaosTypeMemoryStress:
-					ApplyMemoryStress(clientset, chaosConfig)
-				default:
-					fmt.Printf("⚠️  Unknown chaos type: %s\n", config.ChaosType)
+				// Check every run against the safety limits before injecting
+				trigger := Experiment{Chaos: chaosConfig, Safety: config.Safety, Abort: config.Abort, SteadyState: config.SteadyState}
+				if problems := validateExperiment(&trigger, ExperimentSpec{}); len(problems) > 0 {
+					for _, problem := range problems {
+						fmt.Printf("❌ Invalid chaos configuration: %s\n", problem.msg)
+					}
+					continue
+				}
+				err := runGuarded(ctx, env, string(config.ChaosType), injector, chaosConfig, config.SteadyState, config.Abort)
+				if err != nil {
+					fmt.Printf("❌ Cron triggered %s chaos failed: %v\n", config.ChaosType, err)
 				}
 			} else {
 				fmt.Printf("🎲 Cron trigger fired but skipped (probability: %.2f)\n", config.Probability)
@@ -345,81 +533,87 @@ func StartCronTrigger(clientset *kubernetes.Clientset, config CronTriggerConfig)
 	}()
 }
 
-// CleanupChaosJobs cleans up chaos-related jobs
-func CleanupChaosJobs(clientset *kubernetes.Clientset, namespace string) error {
+// CleanupChaosJobs cleans up chaos-related jobs and reverts faults left inside pods.
+// Every cleanup runs even when an earlier one fails; the errors are joined.
+func CleanupChaosJobs(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace string) error {
 	fmt.Printf("🧹 Cleaning up chaos jobs in namespace: %s\n", namespace)
-	
-	// List and delete chaos jobs
-	pods, err := clientset.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
+
+	return errors.Join(
+		deleteChaosJobs(ctx, clientset, namespace),
+		StopStressProcesses(ctx, config, clientset, namespace, ""),
+		CleanupPartitions(ctx, config, clientset, namespace, ""),
+		CleanupDNSChaos(ctx, config, clientset, namespace, ""),
+		CleanupDiskFill(ctx, config, clientset, namespace, ""),
+		CleanupTimeSkew(ctx, config, clientset, namespace, ""),
+		RestoreScaledWorkloads(ctx, clientset, namespace, "", false),
+		CleanupNetworkChaos(ctx, config, clientset, namespace, ""),
+	)
+}
+
+// deleteChaosJobs deletes every helper pod kubechaos created in the namespace
+func deleteChaosJobs(ctx context.Context, clientset *kubernetes.Clientset, namespace string) error {
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: "chaos-type",
 	})
 	if err != nil {
 		return fmt.Errorf("failed to list chaos jobs: %v", err)
 	}
 
+	var failed []string
 	for _, pod := range pods.Items {
 		fmt.Printf("🗑️  Deleting chaos job: %s\n", pod.Name)
-		err := clientset.CoreV1().Pods(namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
+		err := clientset.CoreV1().Pods(namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
 		if err != nil {
 			fmt.Printf("⚠️  Failed to delete chaos job %s: %v\n", pod.Name, err)
+			failed = append(failed, pod.Name)
 		}
 	}
 
-	fmt.Printf("✅ Cleaned up %d chaos jobs\n", len(pods.Items))
+	fmt.Printf("✅ Cleaned up %d chaos jobs\n", len(pods.Items)-len(failed))
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to delete chaos jobs %v", failed)
+	}
 	return nil
 }
 
-// ApplyInPodCPUStress execs into the main container of the pod and runs stress-ng
-func ApplyInPodCPUStress(config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
-	fmt.Printf("🔥 Applying IN-POD CPU stress chaos to namespace: %s\n", chaosConfig.Namespace)
-
-	listOptions := metav1.ListOptions{}
-	if len(chaosConfig.Labels) > 0 {
-		labelSelector := ""
-		for k, v := range chaosConfig.Labels {
-			if labelSelector != "" {
-				labelSelector += ","
-			}
-			labelSelector += fmt.Sprintf("%s=%s", k, v)
-		}
-		listOptions.LabelSelector = labelSelector
-	}
-
-	pods, err := clientset.CoreV1().Pods(chaosConfig.Namespace).List(context.TODO(), listOptions)
+// deleteStressPods deletes the helper pods created for the given chaos type by
+// the run, or by any run when runID is empty
+func deleteStressPods(ctx context.Context, clientset *kubernetes.Clientset, namespace string, chaosType ChaosType, runID string) error {
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: runSelector(fmt.Sprintf("chaos-type=%s", chaosType), runID),
+	})
 	if err != nil {
-		return fmt.Errorf("failed to list pods: %v", err)
-	}
-	if len(pods.Items) == 0 {
-		return fmt.Errorf("no pods found in namespace %s", chaosConfig.Namespace)
+		return fmt.Errorf("failed to list %s pods: %v", chaosType, err)
 	}
 
-	// Filter out chaos-related pods to avoid targeting our own stress pods
-	var availablePods []v1.Pod
 	for _, pod := range pods.Items {
-		// Skip pods that have chaos-type labels (our own stress pods)
-		if pod.Labels["chaos-type"] != "" {
-			continue
-		}
-		// Skip pods that are being terminated or in error state
-		if pod.Status.Phase != v1.PodSucceeded && 
-		   pod.Status.Phase != v1.PodFailed && 
-		   pod.DeletionTimestamp == nil {
-			availablePods = append(availablePods, pod)
+		fmt.Printf("🗑️  Deleting %s pod: %s\n", chaosType, pod.Name)
+		err := clientset.CoreV1().Pods(namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to delete %s pod %s: %v", chaosType, pod.Name, err)
 		}
 	}
+	return nil
+}
 
-	if len(availablePods) == 0 {
-		return fmt.Errorf("no available pods found in namespace %s (excluding chaos pods)", chaosConfig.Namespace)
-	}
+// ApplyInPodCPUStress execs into the main container of the pod and runs stress-ng
+func ApplyInPodCPUStress(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
+	fmt.Printf("🔥 Applying IN-POD CPU stress chaos to namespace: %s\n", chaosConfig.namespaceScope())
 
-	podsToStress := chaosConfig.TargetCount
-	if podsToStress > len(availablePods) {
-		podsToStress = len(availablePods)
-		fmt.Printf("⚠️  Requested to stress %d pods but only %d are available\n", chaosConfig.TargetCount, len(availablePods))
+	selectedPods, err := selectTargets(ctx, clientset, chaosConfig)
+	if err != nil {
+		return err
+	}
+	if chaosConfig.DryRun {
+		printDryRun("No stress will be started", "stress the CPU of", selectedPods,
+			generateStressCommand(StressCommandCPU, chaosConfig.Intensity, chaosConfig.Duration))
+		return nil
 	}
-	selectedPods := selectRandomPods(availablePods, podsToStress)
 
 	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		// Get the actual container name from the pod spec
 		containerName := ""
 		if len(pod.Spec.Containers) > 0 {
@@ -444,7 +638,7 @@ func ApplyInPodCPUStress(config *rest.Config, clientset *kubernetes.Clientset, c
 		fmt.Printf("🔥 Stressing pod %d/%d: %s (container: %s)\n", i+1, len(selectedPods), pod.Name, containerName)
 		fmt.Printf("📋 Command: %s\n", cmd)
 		
-		err := execInPod(config, clientset, chaosConfig.Namespace, pod.Name, containerName, cmd)
+		err := execStress(ctx, config, clientset, pod.Namespace, pod.Name, containerName, cmd, chaosConfig.RunID)
 		if err != nil {
 			fmt.Printf("❌ Failed to exec in pod %s: %v\n", pod.Name, err)
 			// Try with the first container name if it's different
@@ -452,7 +646,7 @@ func ApplyInPodCPUStress(config *rest.Config, clientset *kubernetes.Clientset, c
 				firstContainer := pod.Spec.Containers[0].Name
 				if firstContainer != containerName {
 					fmt.Printf("🔄 Retrying with container: %s\n", firstContainer)
-					err = execInPod(config, clientset, chaosConfig.Namespace, pod.Name, firstContainer, cmd)
+					err = execStress(ctx, config, clientset, pod.Namespace, pod.Name, firstContainer, cmd, chaosConfig.RunID)
 					if err != nil {
 						fmt.Printf("❌ Failed to exec in pod %s with container %s: %v\n", pod.Name, firstContainer, err)
 					} else {
@@ -468,54 +662,23 @@ func ApplyInPodCPUStress(config *rest.Config, clientset *kubernetes.Clientset, c
 }
 
 // ApplyInPodMemoryStress execs into the main container and runs memory stress
-func ApplyInPodMemoryStress(config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
-	fmt.Printf("💾 Applying IN-POD memory stress chaos to namespace: %s\n", chaosConfig.Namespace)
-
-	listOptions := metav1.ListOptions{}
-	if len(chaosConfig.Labels) > 0 {
-		labelSelector := ""
-		for k, v := range chaosConfig.Labels {
-			if labelSelector != "" {
-				labelSelector += ","
-			}
-			labelSelector += fmt.Sprintf("%s=%s", k, v)
-		}
-		listOptions.LabelSelector = labelSelector
-	}
+func ApplyInPodMemoryStress(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
+	fmt.Printf("💾 Applying IN-POD memory stress chaos to namespace: %s\n", chaosConfig.namespaceScope())
 
-	pods, err := clientset.CoreV1().Pods(chaosConfig.Namespace).List(context.TODO(), listOptions)
+	selectedPods, err := selectTargets(ctx, clientset, chaosConfig)
 	if err != nil {
-		return fmt.Errorf("failed to list pods: %v", err)
-	}
-	if len(pods.Items) == 0 {
-		return fmt.Errorf("no pods found in namespace %s", chaosConfig.Namespace)
-	}
-
-	// Filter out chaos-related pods
-	var availablePods []v1.Pod
-	for _, pod := range pods.Items {
-		if pod.Labels["chaos-type"] != "" {
-			continue
-		}
-		if pod.Status.Phase != v1.PodSucceeded && 
-		   pod.Status.Phase != v1.PodFailed && 
-		   pod.DeletionTimestamp == nil {
-			availablePods = append(availablePods, pod)
-		}
-	}
-
-	if len(availablePods) == 0 {
-		return fmt.Errorf("no available pods found in namespace %s (excluding chaos pods)", chaosConfig.Namespace)
+		return err
 	}
-
-	podsToStress := chaosConfig.TargetCount
-	if podsToStress > len(availablePods) {
-		podsToStress = len(availablePods)
-		fmt.Printf("⚠️  Requested to stress %d pods but only %d are available\n", chaosConfig.TargetCount, len(availablePods))
+	if chaosConfig.DryRun {
+		printDryRun("No stress will be started", "stress the memory of", selectedPods,
+			generateStressCommand(StressCommandMemory, chaosConfig.Intensity, chaosConfig.Duration))
+		return nil
 	}
-	selectedPods := selectRandomPods(availablePods, podsToStress)
 
 	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		containerName := ""
 		if len(pod.Spec.Containers) > 0 {
 			containerName = pod.Spec.Containers[0].Name
@@ -528,7 +691,7 @@ func ApplyInPodMemoryStress(config *rest.Config, clientset *kubernetes.Clientset
 		fmt.Printf("💾 Stressing memory for pod %d/%d: %s (container: %s)\n", i+1, len(selectedPods), pod.Name, containerName)
 		fmt.Printf("📋 Command: %s\n", cmd)
 		
-		err := execInPod(config, clientset, chaosConfig.Namespace, pod.Name, containerName, cmd)
+		err := execStress(ctx, config, clientset, pod.Namespace, pod.Name, containerName, cmd, chaosConfig.RunID)
 		if err != nil {
 			fmt.Printf("❌ Failed to exec in pod %s: %v\n", pod.Name, err)
 		} else {
@@ -539,54 +702,23 @@ func ApplyInPodMemoryStress(config *rest.Config, clientset *kubernetes.Clientset
 }
 
 // ApplyInPodMixedStress execs into the main container and runs mixed stress
-func ApplyInPodMixedStress(config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
-	fmt.Printf("🌪️  Applying IN-POD mixed stress chaos to namespace: %s\n", chaosConfig.Namespace)
-
-	listOptions := metav1.ListOptions{}
-	if len(chaosConfig.Labels) > 0 {
-		labelSelector := ""
-		for k, v := range chaosConfig.Labels {
-			if labelSelector != "" {
-				labelSelector += ","
-			}
-			labelSelector += fmt.Sprintf("%s=%s", k, v)
-		}
-		listOptions.LabelSelector = labelSelector
-	}
+func ApplyInPodMixedStress(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
+	fmt.Printf("🌪️  Applying IN-POD mixed stress chaos to namespace: %s\n", chaosConfig.namespaceScope())
 
-	pods, err := clientset.CoreV1().Pods(chaosConfig.Namespace).List(context.TODO(), listOptions)
+	selectedPods, err := selectTargets(ctx, clientset, chaosConfig)
 	if err != nil {
-		return fmt.Errorf("failed to list pods: %v", err)
-	}
-	if len(pods.Items) == 0 {
-		return fmt.Errorf("no pods found in namespace %s", chaosConfig.Namespace)
-	}
-
-	// Filter out chaos-related pods
-	var availablePods []v1.Pod
-	for _, pod := range pods.Items {
-		if pod.Labels["chaos-type"] != "" {
-			continue
-		}
-		if pod.Status.Phase != v1.PodSucceeded && 
-		   pod.Status.Phase != v1.PodFailed && 
-		   pod.DeletionTimestamp == nil {
-			availablePods = append(availablePods, pod)
-		}
-	}
-
-	if len(availablePods) == 0 {
-		return fmt.Errorf("no available pods found in namespace %s (excluding chaos pods)", chaosConfig.Namespace)
+		return err
 	}
-
-	podsToStress := chaosConfig.TargetCount
-	if podsToStress > len(availablePods) {
-		podsToStress = len(availablePods)
-		fmt.Printf("⚠️  Requested to stress %d pods but only %d are available\n", chaosConfig.TargetCount, len(availablePods))
+	if chaosConfig.DryRun {
+		printDryRun("No stress will be started", "run mixed stress in", selectedPods,
+			generateStressCommand(StressCommandMixed, chaosConfig.Intensity, chaosConfig.Duration))
+		return nil
 	}
-	selectedPods := selectRandomPods(availablePods, podsToStress)
 
 	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		containerName := ""
 		if len(pod.Spec.Containers) > 0 {
 			containerName = pod.Spec.Containers[0].Name
@@ -599,7 +731,7 @@ func ApplyInPodMixedStress(config *rest.Config, clientset *kubernetes.Clientset,
 		fmt.Printf("🌪️  Stressing pod %d/%d: %s (container: %s)\n", i+1, len(selectedPods), pod.Name, containerName)
 		fmt.Printf("📋 Command: %s\n", cmd)
 		
-		err := execInPod(config, clientset, chaosConfig.Namespace, pod.Name, containerName, cmd)
+		err := execStress(ctx, config, clientset, pod.Namespace, pod.Name, containerName, cmd, chaosConfig.RunID)
 		if err != nil {
 			fmt.Printf("❌ Failed to exec in pod %s: %v\n", pod.Name, err)
 		} else {
@@ -609,8 +741,61 @@ func ApplyInPodMixedStress(config *rest.Config, clientset *kubernetes.Clientset,
 	return nil
 }
 
+// ApplyInPodIOStress execs into the main container and runs disk I/O stress
+func ApplyInPodIOStress(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
+	fmt.Printf("💽 Applying IN-POD I/O stress chaos to namespace: %s\n", chaosConfig.namespaceScope())
+
+	selectedPods, err := selectTargets(ctx, clientset, chaosConfig)
+	if err != nil {
+		return err
+	}
+	if chaosConfig.DryRun {
+		printDryRun("No I/O stress will be started", "stress the I/O of", selectedPods,
+			generateStressCommand(StressCommandIO, chaosConfig.Intensity, chaosConfig.Duration))
+		return nil
+	}
+
+	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if len(pod.Spec.Containers) == 0 {
+			fmt.Printf("⚠️  Pod %s has no containers, skipping\n", pod.Name)
+			continue
+		}
+		containerName := pod.Spec.Containers[0].Name
+
+		cmd := generateStressCommand(StressCommandIO, chaosConfig.Intensity, chaosConfig.Duration)
+		fmt.Printf("💽 Stressing I/O for pod %d/%d: %s (container: %s)\n", i+1, len(selectedPods), pod.Name, containerName)
+		fmt.Printf("📋 Command: %s\n", cmd)
+
+		err := execStress(ctx, config, clientset, pod.Namespace, pod.Name, containerName, cmd, chaosConfig.RunID)
+		if err != nil {
+			fmt.Printf("❌ Failed to exec in pod %s: %v\n", pod.Name, err)
+		} else {
+			fmt.Printf("✅ Successfully stressed I/O for pod: %s\n", pod.Name)
+		}
+	}
+	return nil
+}
+
+// printDryRun lists the pods a dry run would fault and the command it would run in them
+func printDryRun(nothing, action string, pods []v1.Pod, cmd string) {
+	fmt.Printf("🔍 DRY RUN MODE - %s\n", nothing)
+	fmt.Printf("📋 Would %s %d pods:\n", action, len(pods))
+	for i, pod := range pods {
+		fmt.Printf("  %d. %s/%s\n", i+1, pod.Namespace, pod.Name)
+	}
+	fmt.Printf("📋 Command: %s\n", cmd)
+}
+
 // execInPod runs a shell command in the specified container of a pod
-func execInPod(config *rest.Config, clientset *kubernetes.Clientset, namespace, podName, containerName, command string) error {
+func execInPod(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, podName, containerName, command string) error {
+	return execInPodTo(ctx, config, clientset, namespace, podName, containerName, command, os.Stdout)
+}
+
+// execInPodTo runs a shell command in the specified container of a pod, writing its output to stdout
+func execInPodTo(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, podName, containerName, command string, stdout io.Writer) error {
 	req := clientset.CoreV1().RESTClient().Post().
 		Resource("pods").
 		Name(podName).
@@ -632,14 +817,120 @@ func execInPod(config *rest.Config, clientset *kubernetes.Clientset, namespace,
 		return err
 	}
 
-	return executor.Stream(remotecommand.StreamOptions{
-		Stdout: os.Stdout,
+	return executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: stdout,
 		Stderr: os.Stderr,
 	})
 }
 
+// execInPodOutput runs a shell command in the specified container of a pod and returns its output
+func execInPodOutput(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, podName, containerName, command string) (string, error) {
+	var output bytes.Buffer
+	err := execInPodTo(ctx, config, clientset, namespace, podName, containerName, command, &output)
+	return output.String(), err
+}
+
+// stressChaosAnnotation marks pods that currently run a stress command started by
+// kubechaos; its value is the container the command runs in
+const stressChaosAnnotation = "kubechaos.io/stress"
+
+// stopStressCommand kills the stress processes started by generateStressCommand and
+// removes the I/O stress files. The bracketed patterns keep pkill from matching the
+// shell running this command.
+const stopStressCommand = "for p in '[s]tress-ng' '[d]d if=/dev/zero of=/dev/null' '[w]hile true; do :; done' '[k]ubechaos-io-'; do " +
+	"pkill -f \"$p\" 2>/dev/null || ps -eo pid,args | grep -e \"$p\" | awk '{print $1}' | xargs -r kill 2>/dev/null; done; rm -f /tmp/.kubechaos-i[o]-*; true"
+
+// execStress runs a stress command in the container. The pod is marked for the
+// duration of the command so that an abort or -cleanup can stop it.
+func execStress(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, podName, containerName, command, runID string) error {
+	if err := markPod(ctx, clientset, namespace, podName, stressChaosAnnotation, containerName, runID); err != nil {
+		return fmt.Errorf("failed to annotate pod: %v", err)
+	}
+	defer func() {
+		if ctx.Err() != nil {
+			// Interrupted: the marker stays so the rollback can stop the processes
+			return
+		}
+		if err := patchPodAnnotation(ctx, clientset, namespace, podName, stressChaosAnnotation, nil); err != nil {
+			fmt.Printf("⚠️  Failed to remove stress annotation from pod %s: %v\n", podName, err)
+		}
+	}()
+	return execInPod(ctx, config, clientset, namespace, podName, containerName, command)
+}
+
+// StopStressProcesses kills the stress commands the run is running in the
+// namespace, or those of any run when runID is empty
+func StopStressProcesses(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, runID string) error {
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	var failed []string
+	for _, pod := range pods.Items {
+		containerName, ok := pod.Annotations[stressChaosAnnotation]
+		if !ok || !ownedByRun(pod.Annotations, stressChaosAnnotation, runID) {
+			continue
+		}
+		fmt.Printf("🛑 Stopping stress processes in pod: %s (container: %s)\n", pod.Name, containerName)
+		if err := execInPod(ctx, config, clientset, namespace, pod.Name, containerName, stopStressCommand); err != nil {
+			fmt.Printf("❌ Failed to stop stress processes in pod %s: %v\n", pod.Name, err)
+			failed = append(failed, pod.Name)
+			continue
+		}
+		if err := patchPodAnnotation(ctx, clientset, namespace, pod.Name, stressChaosAnnotation, nil); err != nil {
+			fmt.Printf("⚠️  Failed to remove stress annotation from pod %s: %v\n", pod.Name, err)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to stop stress processes in pods %v", failed)
+	}
+	return nil
+}
+
+// revertStressProcesses is the Revert of the in-pod stress chaos types
+func revertStressProcesses(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+	return forEachTargetNamespace(ctx, env.Clientset, config, func(namespace string) error {
+		return StopStressProcesses(ctx, env.RestConfig, env.Clientset, namespace, config.RunID)
+	})
+}
+
+// markPod sets a fault marker annotation on the pod, together with the run ID of the fault
+func markPod(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName, key string, value interface{}, runID string) error {
+	var run interface{}
+	if runID != "" {
+		run = runID
+	}
+	return patchPodAnnotations(ctx, clientset, namespace, podName, map[string]interface{}{key: value, runAnnotation(key): run})
+}
+
+// patchPodAnnotation sets an annotation on the pod, or removes it together with
+// its run ID when value is nil
+func patchPodAnnotation(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName, key string, value interface{}) error {
+	annotations := map[string]interface{}{key: value}
+	if value == nil {
+		annotations[runAnnotation(key)] = nil
+	}
+	return patchPodAnnotations(ctx, clientset, namespace, podName, annotations)
+}
+
+// patchPodAnnotations sets the annotations on the pod; nil values remove them
+func patchPodAnnotations(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName string, annotations map[string]interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = clientset.CoreV1().Pods(namespace).Patch(ctx, podName, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
 // MonitorPodHealth monitors pod health during stress testing
-func MonitorPodHealth(clientset *kubernetes.Clientset, namespace, podName string, duration time.Duration) {
+func MonitorPodHealth(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName string, duration time.Duration) {
 	fmt.Printf("🔍 Monitoring pod health: %s for %s\n", podName, duration.String())
 	
 	ticker := time.NewTicker(5 * time.Second)
@@ -656,7 +947,7 @@ func MonitorPodHealth(clientset *kubernetes.Clientset, namespace, podName string
 			}
 			
 			// Get current pod status
-			pod, err := clientset.CoreV1().Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
+			pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
 			if err != nil {
 				fmt.Printf("❌ Failed to get pod status: %v\n", err)
 				continue
@@ -687,6 +978,8 @@ func MonitorPodHealth(clientset *kubernetes.Clientset, namespace, podName string
 				}
 			}
 			
+		case <-ctx.Done():
+			return
 		case <-time.After(duration):
 			fmt.Printf("✅ Monitoring completed for pod: %s\n", podName)
 			return
@@ -695,52 +988,13 @@ func MonitorPodHealth(clientset *kubernetes.Clientset, namespace, podName string
 }
 
 // ApplyInPodCPUStressWithMonitoring applies CPU stress with health monitoring
-func ApplyInPodCPUStressWithMonitoring(config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
-	fmt.Printf("🔥 Applying IN-POD CPU stress chaos with monitoring to namespace: %s\n", chaosConfig.Namespace)
-
-	listOptions := metav1.ListOptions{}
-	if len(chaosConfig.Labels) > 0 {
-		labelSelector := ""
-		for k, v := range chaosConfig.Labels {
-			if labelSelector != "" {
-				labelSelector += ","
-			}
-			labelSelector += fmt.Sprintf("%s=%s", k, v)
-		}
-		listOptions.LabelSelector = labelSelector
-	}
+func ApplyInPodCPUStressWithMonitoring(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
+	fmt.Printf("🔥 Applying IN-POD CPU stress chaos with monitoring to namespace: %s\n", chaosConfig.namespaceScope())
 
-	pods, err := clientset.CoreV1().Pods(chaosConfig.Namespace).List(context.TODO(), listOptions)
+	selectedPods, err := selectTargets(ctx, clientset, chaosConfig)
 	if err != nil {
-		return fmt.Errorf("failed to list pods: %v", err)
-	}
-	if len(pods.Items) == 0 {
-		return fmt.Errorf("no pods found in namespace %s", chaosConfig.Namespace)
-	}
-
-	// Filter out chaos-related pods
-	var availablePods []v1.Pod
-	for _, pod := range pods.Items {
-		if pod.Labels["chaos-type"] != "" {
-			continue
-		}
-		if pod.Status.Phase != v1.PodSucceeded && 
-		   pod.Status.Phase != v1.PodFailed && 
-		   pod.DeletionTimestamp == nil {
-			availablePods = append(availablePods, pod)
-		}
-	}
-
-	if len(availablePods) == 0 {
-		return fmt.Errorf("no available pods found in namespace %s (excluding chaos pods)", chaosConfig.Namespace)
-	}
-
-	podsToStress := chaosConfig.TargetCount
-	if podsToStress > len(availablePods) {
-		podsToStress = len(availablePods)
-		fmt.Printf("⚠️  Requested to stress %d pods but only %d are available\n", chaosConfig.TargetCount, len(availablePods))
+		return err
 	}
-	selectedPods := selectRandomPods(availablePods, podsToStress)
 
 	for i, pod := range selectedPods {
 		containerName := ""
@@ -756,9 +1010,9 @@ func ApplyInPodCPUStressWithMonitoring(config *rest.Config, clientset *kubernete
 		fmt.Printf("📋 Command: %s\n", cmd)
 		
 		// Start monitoring in background
-		go MonitorPodHealth(clientset, chaosConfig.Namespace, pod.Name, chaosConfig.Duration)
+		go MonitorPodHealth(ctx, clientset, pod.Namespace, pod.Name, chaosConfig.Duration)
 		
-		err := execInPod(config, clientset, chaosConfig.Namespace, pod.Name, containerName, cmd)
+		err := execInPod(ctx, config, clientset, pod.Namespace, pod.Name, containerName, cmd)
 		if err != nil {
 			fmt.Printf("❌ Failed to exec in pod %s: %v\n", pod.Name, err)
 		} else {
@@ -768,130 +1022,28 @@ func ApplyInPodCPUStressWithMonitoring(config *rest.Config, clientset *kubernete
 	return nil
 }
 
-// ApplyKillProcessChaos kills random processes in the pod
-func ApplyKillProcessChaos(config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
-	fmt.Printf("💀 Applying KILL PROCESS chaos to namespace: %s\n", chaosConfig.Namespace)
-
-	listOptions := metav1.ListOptions{}
-	if len(chaosConfig.Labels) > 0 {
-		labelSelector := ""
-		for k, v := range chaosConfig.Labels {
-			if labelSelector != "" {
-				labelSelector += ","
-			}
-			labelSelector += fmt.Sprintf("%s=%s", k, v)
-		}
-		listOptions.LabelSelector = labelSelector
-	}
-
-	pods, err := clientset.CoreV1().Pods(chaosConfig.Namespace).List(context.TODO(), listOptions)
-	if err != nil {
-		return fmt.Errorf("failed to list pods: %v", err)
-	}
-	if len(pods.Items) == 0 {
-		return fmt.Errorf("no pods found in namespace %s", chaosConfig.Namespace)
-	}
-
-	// Filter out chaos-related pods
-	var availablePods []v1.Pod
-	for _, pod := range pods.Items {
-		if pod.Labels["chaos-type"] != "" {
-			continue
-		}
-		if pod.Status.Phase != v1.PodSucceeded && 
-		   pod.Status.Phase != v1.PodFailed && 
-		   pod.DeletionTimestamp == nil {
-			availablePods = append(availablePods, pod)
-		}
-	}
-
-	if len(availablePods) == 0 {
-		return fmt.Errorf("no available pods found in namespace %s (excluding chaos pods)", chaosConfig.Namespace)
-	}
-
-	podsToStress := chaosConfig.TargetCount
-	if podsToStress > len(availablePods) {
-		podsToStress = len(availablePods)
-		fmt.Printf("⚠️  Requested to stress %d pods but only %d are available\n", chaosConfig.TargetCount, len(availablePods))
-	}
-	selectedPods := selectRandomPods(availablePods, podsToStress)
-
-	for i, pod := range selectedPods {
-		containerName := ""
-		if len(pod.Spec.Containers) > 0 {
-			containerName = pod.Spec.Containers[0].Name
-		} else {
-			fmt.Printf("⚠️  Pod %s has no containers, skipping\n", pod.Name)
-			continue
-		}
-		
-		// Kill random processes - this can cause actual failures
-		killCmd := fmt.Sprintf("ps aux | grep -v 'ps aux' | grep -v grep | awk '{print $2}' | head -%d | xargs -r kill -9", chaosConfig.Intensity)
-		fmt.Printf("💀 Killing processes in pod %d/%d: %s (container: %s)\n", i+1, len(selectedPods), pod.Name, containerName)
-		fmt.Printf("📋 Command: %s\n", killCmd)
-		
-		// Start monitoring in background
-		go MonitorPodHealth(clientset, chaosConfig.Namespace, pod.Name, chaosConfig.Duration)
-		
-		err := execInPod(config, clientset, chaosConfig.Namespace, pod.Name, containerName, killCmd)
-		if err != nil {
-			fmt.Printf("❌ Failed to kill processes in pod %s: %v\n", pod.Name, err)
-		} else {
-			fmt.Printf("✅ Successfully killed processes in pod: %s\n", pod.Name)
-		}
-	}
-	return nil
+// corruptMemoryCommand writes random data to /dev/mem, in MiB scaled by intensity
+func corruptMemoryCommand(config ChaosConfig) string {
+	return fmt.Sprintf("dd if=/dev/urandom of=/dev/mem bs=1M count=%d 2>/dev/null || echo 'Memory corruption attempted'", config.Intensity)
 }
 
 // ApplyCorruptMemoryChaos corrupts memory in the pod
-func ApplyCorruptMemoryChaos(config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
-	fmt.Printf("💥 Applying CORRUPT MEMORY chaos to namespace: %s\n", chaosConfig.Namespace)
-
-	listOptions := metav1.ListOptions{}
-	if len(chaosConfig.Labels) > 0 {
-		labelSelector := ""
-		for k, v := range chaosConfig.Labels {
-			if labelSelector != "" {
-				labelSelector += ","
-			}
-			labelSelector += fmt.Sprintf("%s=%s", k, v)
-		}
-		listOptions.LabelSelector = labelSelector
-	}
+func ApplyCorruptMemoryChaos(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
+	fmt.Printf("💥 Applying CORRUPT MEMORY chaos to namespace: %s\n", chaosConfig.namespaceScope())
 
-	pods, err := clientset.CoreV1().Pods(chaosConfig.Namespace).List(context.TODO(), listOptions)
+	selectedPods, err := selectTargets(ctx, clientset, chaosConfig)
 	if err != nil {
-		return fmt.Errorf("failed to list pods: %v", err)
-	}
-	if len(pods.Items) == 0 {
-		return fmt.Errorf("no pods found in namespace %s", chaosConfig.Namespace)
-	}
-
-	// Filter out chaos-related pods
-	var availablePods []v1.Pod
-	for _, pod := range pods.Items {
-		if pod.Labels["chaos-type"] != "" {
-			continue
-		}
-		if pod.Status.Phase != v1.PodSucceeded && 
-		   pod.Status.Phase != v1.PodFailed && 
-		   pod.DeletionTimestamp == nil {
-			availablePods = append(availablePods, pod)
-		}
-	}
-
-	if len(availablePods) == 0 {
-		return fmt.Errorf("no available pods found in namespace %s (excluding chaos pods)", chaosConfig.Namespace)
+		return err
 	}
-
-	podsToStress := chaosConfig.TargetCount
-	if podsToStress > len(availablePods) {
-		podsToStress = len(availablePods)
-		fmt.Printf("⚠️  Requested to stress %d pods but only %d are available\n", chaosConfig.TargetCount, len(availablePods))
+	if chaosConfig.DryRun {
+		printDryRun("No memory will be corrupted", "corrupt memory in", selectedPods, corruptMemoryCommand(chaosConfig))
+		return nil
 	}
-	selectedPods := selectRandomPods(availablePods, podsToStress)
 
 	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		containerName := ""
 		if len(pod.Spec.Containers) > 0 {
 			containerName = pod.Spec.Containers[0].Name
@@ -902,14 +1054,14 @@ func ApplyCorruptMemoryChaos(config *rest.Config, clientset *kubernetes.Clientse
 		
 		// Corrupt memory by writing random data to /dev/mem (if accessible)
 		// This is more aggressive and can cause actual crashes
-		corruptCmd := fmt.Sprintf("dd if=/dev/urandom of=/dev/mem bs=1M count=%d 2>/dev/null || echo 'Memory corruption attempted'", chaosConfig.Intensity)
+		corruptCmd := corruptMemoryCommand(chaosConfig)
 		fmt.Printf("💥 Corrupting memory in pod %d/%d: %s (container: %s)\n", i+1, len(selectedPods), pod.Name, containerName)
 		fmt.Printf("📋 Command: %s\n", corruptCmd)
 		
 		// Start monitoring in background
-		go MonitorPodHealth(clientset, chaosConfig.Namespace, pod.Name, chaosConfig.Duration)
+		go MonitorPodHealth(ctx, clientset, pod.Namespace, pod.Name, chaosConfig.Duration)
 		
-		err := execInPod(config, clientset, chaosConfig.Namespace, pod.Name, containerName, corruptCmd)
+		err := execInPod(ctx, config, clientset, pod.Namespace, pod.Name, containerName, corruptCmd)
 		if err != nil {
 			fmt.Printf("❌ Failed to corrupt memory in pod %s: %v\n", pod.Name, err)
 		} else {
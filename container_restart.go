@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+// containerRestartTimeout bounds how long a container may take to be restarted and running again
+const containerRestartTimeout = 3 * time.Minute
+
+// restartScript runs in the restart helper pod and kills every process of the
+// container from the host PID namespace, so that the kubelet restarts it in place
+const restartScript = containerPidsScript + `kill -KILL $pids
+echo "killed$pids"`
+
+func init() {
+	RegisterInjector(&funcInjector{
+		name: ChaosTypeContainerRestart,
+		inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return ApplyContainerRestartChaos(ctx, env.Clientset, config)
+		},
+		revert: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return forEachTargetNamespace(ctx, env.Clientset, config, func(namespace string) error {
+				return deleteStressPods(ctx, env.Clientset, namespace, ChaosTypeContainerRestart, config.RunID)
+			})
+		},
+	}, "Restart a container in place, without deleting its pod")
+}
+
+// targetContainer returns the container chaos is applied to: the named one, or
+// the first container of the pod when no name is configured
+func targetContainer(pod v1.Pod, name string) (string, bool) {
+	if name == "" {
+		if len(pod.Spec.Containers) == 0 {
+			return "", false
+		}
+		return pod.Spec.Containers[0].Name, true
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// restartCount returns the restart count of the container in the pod status
+func restartCount(pod v1.Pod, container string) int32 {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container {
+			return status.RestartCount
+		}
+	}
+	return 0
+}
+
+// ApplyContainerRestartChaos kills the configured container of the selected pods
+// and verifies that the kubelet restarted it in place
+func ApplyContainerRestartChaos(ctx context.Context, clientset *kubernetes.Clientset, config ChaosConfig) error {
+	fmt.Printf("🔁 Applying CONTAINER RESTART chaos to namespace: %s\n", config.namespaceScope())
+
+	selectedPods, err := selectTargets(ctx, clientset, config)
+	if err != nil {
+		return err
+	}
+
+	if config.DryRun {
+		fmt.Println("🔍 DRY RUN MODE - No container will be restarted")
+		fmt.Printf("📋 Would restart containers in %d pods:\n", len(selectedPods))
+		for i, pod := range selectedPods {
+			container, ok := targetContainer(pod, config.Container)
+			if !ok {
+				container = "no container " + config.Container
+			}
+			fmt.Printf("  %d. %s/%s (%s)\n", i+1, pod.Namespace, pod.Name, container)
+		}
+		return nil
+	}
+
+	restarted := 0
+	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		container, ok := targetContainer(pod, config.Container)
+		if !ok {
+			fmt.Printf("⚠️  Pod %s has no container %q, skipping\n", pod.Name, config.Container)
+			continue
+		}
+		if pod.Spec.RestartPolicy == v1.RestartPolicyNever {
+			fmt.Printf("⚠️  Pod %s has restartPolicy Never, skipping\n", pod.Name)
+			continue
+		}
+		id := containerID(pod, container)
+		if id == "" {
+			fmt.Printf("⚠️  Container %s of pod %s is not running, skipping\n", container, pod.Name)
+			continue
+		}
+
+		before := restartCount(pod, container)
+		fmt.Printf("🔁 Restarting container %d/%d: %s/%s (node: %s, restartCount: %d)\n", i+1, len(selectedPods), pod.Name, container, pod.Spec.NodeName, before)
+		if err := killContainer(ctx, clientset, pod, id, config.RunID); err != nil {
+			fmt.Printf("❌ Failed to restart container %s of pod %s: %v\n", container, pod.Name, err)
+			continue
+		}
+		after, err := waitForContainerRestart(ctx, clientset, pod, container, before)
+		if err != nil {
+			fmt.Printf("❌ Container %s of pod %s was not restarted: %v\n", container, pod.Name, err)
+			continue
+		}
+		fmt.Printf("✅ Container %s of pod %s restarted in place (restartCount: %d → %d)\n", container, pod.Name, before, after)
+		restarted++
+	}
+
+	if restarted == 0 {
+		return fmt.Errorf("failed to restart any container")
+	}
+	return nil
+}
+
+// killContainer runs a helper pod on the node of the pod that kills the processes
+// of the container, and removes the helper once it is done
+func killContainer(ctx context.Context, clientset *kubernetes.Clientset, pod v1.Pod, id, runID string) error {
+	helper, err := createNodeHelper(ctx, clientset, pod, ChaosTypeContainerRestart, "restart", restartScript, runID, []v1.EnvVar{
+		{Name: "CONTAINER_ID", Value: id},
+	})
+	if err != nil {
+		return err
+	}
+	defer deleteNodeHelper(clientset, helper)
+
+	deadline := time.Now().Add(containerRestartTimeout)
+	for time.Now().Before(deadline) {
+		current, err := clientset.CoreV1().Pods(pod.Namespace).Get(ctx, helper.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		switch current.Status.Phase {
+		case v1.PodSucceeded:
+			fmt.Printf("  🔁 %s\n", strings.TrimSpace(helperLogs(ctx, clientset, current)))
+			return nil
+		case v1.PodFailed:
+			return fmt.Errorf("helper pod %s failed: %s", current.Name, strings.TrimSpace(helperLogs(ctx, clientset, current)))
+		}
+		if err := holdChaos(ctx, 2*time.Second); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("helper pod %s did not finish within %s", helper.Name, containerRestartTimeout)
+}
+
+// waitForContainerRestart watches the pod until the restart count of the container
+// is above before and the container runs again, and returns the new count. It
+// fails if the pod is replaced or moves to another node.
+func waitForContainerRestart(ctx context.Context, clientset *kubernetes.Clientset, pod v1.Pod, container string, before int32) (int32, error) {
+	ctx, cancel := context.WithTimeout(ctx, containerRestartTimeout)
+	defer cancel()
+
+	// Without a resource version the watch starts with the current state of the pod
+	watcher, err := clientset.CoreV1().Pods(pod.Namespace).Watch(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", pod.Name).String(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to watch pod: %v", err)
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("restartCount stayed at %d for %s", before, containerRestartTimeout)
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return 0, fmt.Errorf("watch of pod %s closed", pod.Name)
+			}
+			switch event.Type {
+			case watch.Deleted:
+				return 0, fmt.Errorf("pod %s was deleted", pod.Name)
+			case watch.Error:
+				return 0, fmt.Errorf("watch of pod %s failed", pod.Name)
+			}
+			current, ok := event.Object.(*v1.Pod)
+			if !ok {
+				continue
+			}
+			if current.UID != pod.UID || current.Spec.NodeName != pod.Spec.NodeName {
+				return 0, fmt.Errorf("pod %s was replaced", pod.Name)
+			}
+			for _, status := range current.Status.ContainerStatuses {
+				if status.Name == container && status.RestartCount > before && status.State.Running != nil {
+					return status.RestartCount, nil
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// diskFillAnnotation marks pods that currently hold a filler file written by
+// kubechaos and holds the JSON encoded diskFillMarker
+const diskFillAnnotation = "kubechaos.io/disk-fill"
+
+// diskFillFileName is the name of the filler file created in the filled path
+const diskFillFileName = ".kubechaos-fill"
+
+// diskFillPidFile holds the PID of the in-pod revert watchdog
+const diskFillPidFile = "/tmp/.kubechaos-fill.pid"
+
+// diskFillMarker is stored in the diskFillAnnotation of a target pod
+type diskFillMarker struct {
+	Container string `json:"container"`
+	File      string `json:"file"`
+}
+
+func init() {
+	RegisterInjector(&funcInjector{
+		name:     ChaosTypeDiskFill,
+		validate: validateDiskFill,
+		inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return ApplyDiskFillChaos(ctx, env.RestConfig, env.Clientset, config)
+		},
+		revert: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return forEachTargetNamespace(ctx, env.Clientset, config, func(namespace string) error {
+				return CleanupDiskFill(ctx, env.RestConfig, env.Clientset, namespace, config.RunID)
+			})
+		},
+	}, "Fill a filesystem in the target containers to a percentage")
+}
+
+// validateDiskFill checks the settings specific to disk-fill chaos
+func validateDiskFill(config ChaosConfig) error {
+	if config.Disk.FillPercent < 1 || config.Disk.FillPercent > 100 {
+		return fmt.Errorf("fill percent must be between 1 and 100, got %d", config.Disk.FillPercent)
+	}
+	if !path.IsAbs(config.Disk.Path) || strings.ContainsAny(config.Disk.Path, "'\"$`\\\n") {
+		return fmt.Errorf("invalid fill path %q: must be an absolute path", config.Disk.Path)
+	}
+	return nil
+}
+
+// diskFillFile returns the filler file for the filled path
+func diskFillFile(fillPath string) string {
+	return path.Join(fillPath, diskFillFileName)
+}
+
+// diskFillRemoveCommand creates the command that deletes the filler file
+func diskFillRemoveCommand(file string) string {
+	return fmt.Sprintf("rm -f \"%s\"", file)
+}
+
+// generateDiskFillRevertCommand creates the command that deletes the filler file and stops its watchdog
+func generateDiskFillRevertCommand(file string) string {
+	return fmt.Sprintf("(kill $(cat %s) 2>/dev/null; rm -f %s); %s; true", diskFillPidFile, diskFillPidFile, diskFillRemoveCommand(file))
+}
+
+// generateDiskFillCommand creates the command that fills the filesystem holding
+// the path up to the percentage with a single file, preallocated when the
+// filesystem supports it. A watchdog deletes the file even if kubechaos dies.
+func generateDiskFillCommand(disk DiskChaosConfig, duration time.Duration) string {
+	file := diskFillFile(disk.Path)
+	cmd := fmt.Sprintf("set -- $(df -Pk '%s' | awk 'NR==2 {print $2, $3}') && fill=$(( $1 * %d / 100 - $2 )) && ", disk.Path, disk.FillPercent)
+	cmd += fmt.Sprintf("if [ \"$fill\" -gt 0 ]; then fallocate -l ${fill}K '%s' 2>/dev/null || dd if=/dev/zero of='%s' bs=1M count=$(( fill / 1024 )) 2>/dev/null; fi; ", file, file)
+	cmd += fmt.Sprintf("df -Pk '%s' | awk 'NR==2 {print \"disk usage: \" $5 \" of \" $6}'", disk.Path)
+	cmd += fmt.Sprintf(" && (nohup sh -c 'sleep %d; %s' >/dev/null 2>&1 & echo $! > %s)",
+		int((duration + 30*time.Second).Seconds()), diskFillRemoveCommand(file), diskFillPidFile)
+	return cmd
+}
+
+// ApplyDiskFillChaos fills the configured path inside the selected pods for
+// the configured duration, then deletes the filler file
+func ApplyDiskFillChaos(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
+	disk := chaosConfig.Disk
+	fmt.Printf("🗄️  Applying DISK FILL chaos to namespace: %s (%s to %d%%)\n", chaosConfig.namespaceScope(), disk.Path, disk.FillPercent)
+
+	selectedPods, err := selectTargets(ctx, clientset, chaosConfig)
+	if err != nil {
+		return err
+	}
+
+	cmd := generateDiskFillCommand(disk, chaosConfig.Duration)
+	if chaosConfig.DryRun {
+		fmt.Println("🔍 DRY RUN MODE - No disk will be filled")
+		fmt.Printf("📋 Would fill %s in %d pods:\n", disk.Path, len(selectedPods))
+		for i, pod := range selectedPods {
+			fmt.Printf("  %d. %s/%s\n", i+1, pod.Namespace, pod.Name)
+		}
+		fmt.Printf("📋 Command: %s\n", cmd)
+		return nil
+	}
+
+	var filledPods []v1.Pod
+	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			break
+		}
+		if len(pod.Spec.Containers) == 0 {
+			fmt.Printf("⚠️  Pod %s has no containers, skipping\n", pod.Name)
+			continue
+		}
+		if _, busy := pod.Annotations[diskFillAnnotation]; busy {
+			fmt.Printf("⚠️  Pod %s is already being filled, skipping\n", pod.Name)
+			continue
+		}
+		marker := diskFillMarker{
+			Container: pod.Spec.Containers[0].Name,
+			File:      diskFillFile(disk.Path),
+		}
+
+		fmt.Printf("🗄️  Filling disk of pod %d/%d: %s (container: %s, path: %s)\n", i+1, len(selectedPods), pod.Name, marker.Container, disk.Path)
+		fmt.Printf("📋 Command: %s\n", cmd)
+
+		// Record the fault before injecting it so -cleanup can always find it
+		if err := setDiskFillMarker(ctx, clientset, pod.Namespace, pod.Name, &marker, chaosConfig.RunID); err != nil {
+			fmt.Printf("❌ Failed to annotate pod %s: %v\n", pod.Name, err)
+			continue
+		}
+		if err := execInPod(ctx, config, clientset, pod.Namespace, pod.Name, marker.Container, cmd); err != nil {
+			fmt.Printf("❌ Failed to fill disk in pod %s: %v\n", pod.Name, err)
+			revertDiskFill(ctx, config, clientset, pod.Namespace, pod.Name, marker)
+			continue
+		}
+		fmt.Printf("✅ Successfully filled disk of pod: %s\n", pod.Name)
+		filledPods = append(filledPods, pod)
+	}
+
+	if len(filledPods) == 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to fill the disk of any pod")
+	}
+
+	fmt.Printf("⏳ Holding disk fill for %s...\n", chaosConfig.Duration)
+	if holdChaos(ctx, chaosConfig.Duration) != nil {
+		// The markers stay on the pods so the rollback can delete the filler files
+		fmt.Println("🛑 Disk fill interrupted")
+		return ctx.Err()
+	}
+
+	for _, pod := range filledPods {
+		revertDiskFill(ctx, config, clientset, pod.Namespace, pod.Name, diskFillMarker{
+			Container: pod.Spec.Containers[0].Name,
+			File:      diskFillFile(disk.Path),
+		})
+	}
+	return nil
+}
+
+// revertDiskFill deletes the filler file from the pod and clears its marker annotation
+func revertDiskFill(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, podName string, marker diskFillMarker) error {
+	fmt.Printf("🔧 Deleting filler file from pod: %s (%s)\n", podName, marker.File)
+
+	err := execInPod(ctx, config, clientset, namespace, podName, marker.Container, generateDiskFillRevertCommand(marker.File))
+	if err != nil {
+		fmt.Printf("❌ Failed to delete filler file from pod %s: %v\n", podName, err)
+		return err
+	}
+
+	if err := setDiskFillMarker(ctx, clientset, namespace, podName, nil, ""); err != nil {
+		fmt.Printf("⚠️  Failed to remove disk-fill annotation from pod %s: %v\n", podName, err)
+		return err
+	}
+	return nil
+}
+
+// setDiskFillMarker stores the marker and run ID in the pod annotations, or
+// removes them when marker is nil
+func setDiskFillMarker(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName string, marker *diskFillMarker, runID string) error {
+	if marker == nil {
+		return patchPodAnnotation(ctx, clientset, namespace, podName, diskFillAnnotation, nil)
+	}
+	value, err := json.Marshal(marker)
+	if err != nil {
+		return err
+	}
+	return markPod(ctx, clientset, namespace, podName, diskFillAnnotation, string(value), runID)
+}
+
+// CleanupDiskFill deletes the filler files the run left behind in the namespace,
+// or those of any run when runID is empty
+func CleanupDiskFill(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, runID string) error {
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	cleaned := 0
+	var failed []string
+	for _, pod := range pods.Items {
+		value, ok := pod.Annotations[diskFillAnnotation]
+		if !ok || !ownedByRun(pod.Annotations, diskFillAnnotation, runID) {
+			continue
+		}
+		var marker diskFillMarker
+		if err := json.Unmarshal([]byte(value), &marker); err != nil {
+			fmt.Printf("⚠️  Ignoring malformed disk-fill annotation on pod %s: %v\n", pod.Name, err)
+			continue
+		}
+		if err := revertDiskFill(ctx, config, clientset, namespace, pod.Name, marker); err != nil {
+			failed = append(failed, pod.Name)
+			continue
+		}
+		cleaned++
+	}
+	if cleaned > 0 {
+		fmt.Printf("✅ Deleted filler files from %d pods\n", cleaned)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to delete the filler files from pods %v", failed)
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// dnsChaosAnnotation marks pods that currently have a DNS fault injected by
+// kubechaos and holds the container it was injected in
+const dnsChaosAnnotation = "kubechaos.io/dns-chaos"
+
+// dnsChain is the iptables chain holding the DNS fault rules inside a pod
+const dnsChain = "KUBECHAOS-DNS"
+
+// dnsPidFile holds the PID of the in-pod revert watchdog
+const dnsPidFile = "/tmp/.kubechaos-dns.pid"
+
+// dnsHostsMarker tags the /etc/hosts entries added for wrong answers; the
+// revert command removes the lines ending in "kubechaos-dns"
+const dnsHostsMarker = "# kubechaos-dns"
+
+// DNS fault modes
+const (
+	DNSModeFail    = "fail"    // Queries are rejected, so resolution fails at once
+	DNSModeTimeout = "timeout" // Queries are dropped, so resolution times out
+	DNSModeWrong   = "wrong"   // Names resolve to DNSChaosConfig.Answer
+)
+
+// dnsDomainPattern matches the domain names a DNS fault can be limited to
+var dnsDomainPattern = regexp.MustCompile(`^([a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?\.)*[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?\.?$`)
+
+func init() {
+	RegisterInjector(&funcInjector{
+		name:     ChaosTypeDNSFailure,
+		validate: validateDNSChaos,
+		inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return ApplyDNSChaos(ctx, env.RestConfig, env.Clientset, config)
+		},
+		revert: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return forEachTargetNamespace(ctx, env.Clientset, config, func(namespace string) error {
+				return CleanupDNSChaos(ctx, env.RestConfig, env.Clientset, namespace, config.RunID)
+			})
+		},
+	}, "Make name resolution fail, time out or return wrong answers in pods")
+}
+
+// validateDNSChaos checks the settings specific to DNS chaos
+func validateDNSChaos(config ChaosConfig) error {
+	dns := config.DNS
+	switch dns.Mode {
+	case DNSModeFail, DNSModeTimeout:
+	case DNSModeWrong:
+		if len(dns.Domains) == 0 {
+			return fmt.Errorf("dns mode %q needs the domains to answer wrongly for", dns.Mode)
+		}
+		if net.ParseIP(dns.Answer) == nil {
+			return fmt.Errorf("invalid dns answer %q: must be an IP address", dns.Answer)
+		}
+	default:
+		return fmt.Errorf("invalid dns mode %q: must be %s, %s or %s", dns.Mode, DNSModeFail, DNSModeTimeout, DNSModeWrong)
+	}
+	for _, domain := range dns.Domains {
+		if len(domain) > 253 || !dnsDomainPattern.MatchString(domain) {
+			return fmt.Errorf("invalid dns domain %q", domain)
+		}
+	}
+	return nil
+}
+
+// dnsHexString encodes a domain the way it appears in a DNS query, e.g.
+// "example.com" becomes "|07|example|03|com|", for the iptables string match
+func dnsHexString(domain string) string {
+	var encoded strings.Builder
+	for _, label := range strings.Split(strings.TrimSuffix(domain, "."), ".") {
+		fmt.Fprintf(&encoded, "|%02x|%s", len(label), label)
+	}
+	encoded.WriteString("|")
+	return encoded.String()
+}
+
+// dnsRules returns the iptables rule specs that fail or drop the DNS queries
+// for the domains, or every DNS query when there are none
+func dnsRules(mode string, domains []string) []string {
+	udpTarget, tcpTarget := "REJECT", "REJECT --reject-with tcp-reset"
+	if mode == DNSModeTimeout {
+		udpTarget, tcpTarget = "DROP", "DROP"
+	}
+
+	matches := []string{""}
+	if len(domains) > 0 {
+		matches = nil
+		for _, domain := range domains {
+			matches = append(matches, fmt.Sprintf(" -m string --algo bm --icase --hex-string '%s'", dnsHexString(domain)))
+		}
+	}
+
+	var rules []string
+	for _, match := range matches {
+		rules = append(rules,
+			fmt.Sprintf("-p udp --dport 53%s -j %s", match, udpTarget),
+			fmt.Sprintf("-p tcp --dport 53%s -j %s", match, tcpTarget))
+	}
+	return rules
+}
+
+// dnsRemoveCommand creates the command that removes the DNS fault rules and hosts entries
+func dnsRemoveCommand() string {
+	return fmt.Sprintf("iptables -D OUTPUT -j %s 2>/dev/null; iptables -F %s 2>/dev/null; iptables -X %s 2>/dev/null", dnsChain, dnsChain, dnsChain) +
+		"; if grep -q kubechaos-dns$ /etc/hosts 2>/dev/null; then grep -v kubechaos-dns$ /etc/hosts > /tmp/.kubechaos-hosts; cat /tmp/.kubechaos-hosts > /etc/hosts; rm -f /tmp/.kubechaos-hosts; fi" +
+		"; true"
+}
+
+// generateDNSRevertCommand creates the command that removes every DNS fault and its watchdog
+func generateDNSRevertCommand() string {
+	return fmt.Sprintf("(kill $(cat %s) 2>/dev/null; rm -f %s); ", dnsPidFile, dnsPidFile) + dnsRemoveCommand()
+}
+
+// generateDNSCommand creates the command that injects the DNS fault inside a pod.
+// Wrong answers are added to /etc/hosts for the exact names only, as it has no
+// wildcards (it is rewritten in place, since it is usually a bind mount); failures and timeouts are iptables rules on the DNS
+// queries. A watchdog reverts the fault even if kubechaos dies.
+func generateDNSCommand(dns DNSChaosConfig, duration time.Duration) string {
+	var cmd string
+	if dns.Mode == DNSModeWrong {
+		var entries []string
+		for _, domain := range dns.Domains {
+			entries = append(entries, fmt.Sprintf("'%s %s %s'", dns.Answer, strings.TrimSuffix(domain, "."), dnsHostsMarker))
+		}
+		cmd = fmt.Sprintf("printf '%%s\\n' %s >> /etc/hosts", strings.Join(entries, " "))
+	} else {
+		cmd = iptablesInstallCommand + fmt.Sprintf(" && (iptables -N %s 2>/dev/null || true) && iptables -I OUTPUT -j %s", dnsChain, dnsChain)
+		for _, rule := range dnsRules(dns.Mode, dns.Domains) {
+			cmd += fmt.Sprintf(" && iptables -A %s %s", dnsChain, rule)
+		}
+	}
+	cmd += fmt.Sprintf(" && (nohup sh -c 'sleep %d; %s' >/dev/null 2>&1 & echo $! > %s)",
+		int((duration + 30*time.Second).Seconds()), dnsRemoveCommand(), dnsPidFile)
+	return cmd
+}
+
+// describeDNS summarizes the DNS fault for log output
+func describeDNS(dns DNSChaosConfig) string {
+	scope := "all domains"
+	if len(dns.Domains) > 0 {
+		scope = strings.Join(dns.Domains, ", ")
+	}
+	if dns.Mode == DNSModeWrong {
+		return fmt.Sprintf("%s resolve to %s", scope, dns.Answer)
+	}
+	return fmt.Sprintf("lookups of %s %s", scope, map[string]string{DNSModeFail: "fail", DNSModeTimeout: "time out"}[dns.Mode])
+}
+
+// ApplyDNSChaos breaks name resolution inside the selected pods for the
+// configured duration, then restores it
+func ApplyDNSChaos(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
+	fmt.Printf("📛 Applying DNS chaos to namespace: %s (%s)\n", chaosConfig.namespaceScope(), describeDNS(chaosConfig.DNS))
+
+	selectedPods, err := selectTargets(ctx, clientset, chaosConfig)
+	if err != nil {
+		return err
+	}
+
+	cmd := generateDNSCommand(chaosConfig.DNS, chaosConfig.Duration)
+	if chaosConfig.DryRun {
+		fmt.Println("🔍 DRY RUN MODE - No resolver will be changed")
+		fmt.Printf("📋 Would break DNS in %d pods:\n", len(selectedPods))
+		for i, pod := range selectedPods {
+			fmt.Printf("  %d. %s/%s\n", i+1, pod.Namespace, pod.Name)
+		}
+		fmt.Printf("📋 Command: %s\n", cmd)
+		return nil
+	}
+
+	var faultedPods []v1.Pod
+	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			break
+		}
+		if len(pod.Spec.Containers) == 0 {
+			fmt.Printf("⚠️  Pod %s has no containers, skipping\n", pod.Name)
+			continue
+		}
+		if _, busy := pod.Annotations[dnsChaosAnnotation]; busy {
+			fmt.Printf("⚠️  Pod %s already has DNS chaos, skipping\n", pod.Name)
+			continue
+		}
+		container := pod.Spec.Containers[0].Name
+
+		fmt.Printf("📛 Breaking DNS for pod %d/%d: %s (container: %s)\n", i+1, len(selectedPods), pod.Name, container)
+		fmt.Printf("📋 Command: %s\n", cmd)
+
+		// Record the fault before injecting it so -cleanup can always find it
+		if err := markPod(ctx, clientset, pod.Namespace, pod.Name, dnsChaosAnnotation, container, chaosConfig.RunID); err != nil {
+			fmt.Printf("❌ Failed to annotate pod %s: %v\n", pod.Name, err)
+			continue
+		}
+		if err := execInPod(ctx, config, clientset, pod.Namespace, pod.Name, container, cmd); err != nil {
+			fmt.Printf("❌ Failed to apply DNS chaos in pod %s: %v\n", pod.Name, err)
+			revertDNSChaos(ctx, config, clientset, pod.Namespace, pod.Name)
+			continue
+		}
+		fmt.Printf("✅ Successfully applied DNS chaos to pod: %s\n", pod.Name)
+		faultedPods = append(faultedPods, pod)
+	}
+
+	if len(faultedPods) == 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to apply DNS chaos to any pod")
+	}
+
+	fmt.Printf("⏳ Holding DNS chaos for %s...\n", chaosConfig.Duration)
+	if holdChaos(ctx, chaosConfig.Duration) != nil {
+		// The markers stay on the pods so the rollback can restore the resolvers
+		fmt.Println("🛑 DNS chaos interrupted")
+		return ctx.Err()
+	}
+
+	for _, pod := range faultedPods {
+		revertDNSChaos(ctx, config, clientset, pod.Namespace, pod.Name)
+	}
+	return nil
+}
+
+// revertDNSChaos removes the DNS fault from the pod and clears its marker annotation
+func revertDNSChaos(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, podName string) error {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	container, ok := pod.Annotations[dnsChaosAnnotation]
+	if !ok {
+		return nil
+	}
+
+	fmt.Printf("🔧 Restoring DNS in pod: %s\n", podName)
+	if err := execInPod(ctx, config, clientset, namespace, podName, container, generateDNSRevertCommand()); err != nil {
+		fmt.Printf("❌ Failed to restore DNS in pod %s: %v\n", podName, err)
+		return err
+	}
+	if err := patchPodAnnotation(ctx, clientset, namespace, podName, dnsChaosAnnotation, nil); err != nil {
+		fmt.Printf("⚠️  Failed to remove DNS chaos annotation from pod %s: %v\n", podName, err)
+		return err
+	}
+	return nil
+}
+
+// CleanupDNSChaos restores DNS in the pods the run left broken in the namespace,
+// or in those of any run when runID is empty
+func CleanupDNSChaos(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, runID string) error {
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	cleaned := 0
+	var failed []string
+	for _, pod := range pods.Items {
+		if _, ok := pod.Annotations[dnsChaosAnnotation]; !ok || !ownedByRun(pod.Annotations, dnsChaosAnnotation, runID) {
+			continue
+		}
+		if err := revertDNSChaos(ctx, config, clientset, namespace, pod.Name); err != nil {
+			failed = append(failed, pod.Name)
+			continue
+		}
+		cleaned++
+	}
+	if cleaned > 0 {
+		fmt.Printf("✅ Restored DNS in %d pods\n", cleaned)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to restore DNS in pods %v", failed)
+	}
+	return nil
+}
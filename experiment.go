@@ -0,0 +1,719 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iamkrati22/kubechaos/targeting"
+	"github.com/robfig/cron/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// Experiment is a single chaos run, loaded from an experiment file or built from flags
+type Experiment struct {
+	Name        string
+	Chaos       ChaosConfig
+	Schedule    string  // Cron schedule; empty runs the experiment once
+	Probability float64 // Probability of a scheduled trigger firing (0.0-1.0)
+	Safety      SafetyLimits
+	SteadyState SteadyState // No probes means the experiment is not judged
+	Abort       AbortConditions
+	// Randomize intensity and target count on every scheduled trigger, within
+	// the safety limits; only the ad-hoc -cron mode sets it
+	RandomizeTriggers bool
+}
+
+// SafetyLimits bound what an experiment is allowed to do
+type SafetyLimits struct {
+	MaxTargets   int           // 0 means no limit
+	MaxDuration  time.Duration // 0 means no limit
+	MaxIntensity int           // 0 means no limit
+}
+
+// ExperimentSpec is the file representation of an experiment.
+// Fields that are left out keep the value of the corresponding flag.
+type ExperimentSpec struct {
+	Name        string           `yaml:"name"`
+	ChaosType   string           `yaml:"chaosType"`
+	Targets     TargetSpec       `yaml:"targets"`
+	Container   string           `yaml:"container"`
+	Intensity   *int             `yaml:"intensity"`
+	Duration    string           `yaml:"duration"`
+	Probability *float64         `yaml:"probability"`
+	Schedule    string           `yaml:"schedule"`
+	Network     NetworkSpec      `yaml:"network"`
+	Node        NodeSpec         `yaml:"node"`
+	DNS         DNSSpec          `yaml:"dns"`
+	Disk        DiskSpec         `yaml:"disk"`
+	Time        TimeSpec         `yaml:"time"`
+	Process     ProcessSpec      `yaml:"process"`
+	Workload    WorkloadSpec     `yaml:"workload"`
+	Safety      SafetySpec       `yaml:"safety"`
+	SteadyState *SteadyStateSpec `yaml:"steadyState"`
+	Abort       *AbortSpec       `yaml:"abort"`
+}
+
+// TargetSpec selects the pods an experiment is applied to
+type TargetSpec struct {
+	Namespace         string   `yaml:"namespace"`
+	Namespaces        []string `yaml:"namespaces"`
+	NamespaceSelector string   `yaml:"namespaceSelector"`
+	AllNamespaces     bool     `yaml:"allNamespaces"`
+	Labels            string   `yaml:"labels"`
+	FieldSelector     string   `yaml:"fieldSelector"`
+	ReadyOnly         *bool    `yaml:"readyOnly"`
+	Count             *int     `yaml:"count"`
+	Percent           int      `yaml:"percent"`
+	Min               int      `yaml:"min"`
+	Max               int      `yaml:"max"`
+	WeightBy          string   `yaml:"weightBy"`
+	Selection         string   `yaml:"selection"`
+	MaxPerOwner       *int     `yaml:"maxPerOwner"`
+	OwnerKind         string   `yaml:"ownerKind"`
+}
+
+// NetworkSpec holds the network chaos settings of an experiment
+type NetworkSpec struct {
+	Interface    string   `yaml:"interface"`
+	Latency      string   `yaml:"latency"`
+	Jitter       string   `yaml:"jitter"`
+	Loss         *float64 `yaml:"loss"`
+	Corrupt      *float64 `yaml:"corrupt"`
+	Duplicate    *float64 `yaml:"duplicate"`
+	Reorder      *float64 `yaml:"reorder"`
+	Rate         string   `yaml:"rate"`
+	Destinations []string `yaml:"destinations"`
+	Ports        []int    `yaml:"ports"`
+	PeerLabels   string   `yaml:"peerLabels"`
+}
+
+// NodeSpec selects the nodes of node-scoped chaos types
+type NodeSpec struct {
+	Labels string   `yaml:"labels"`
+	Zones  []string `yaml:"zones"`
+}
+
+// DNSSpec holds the DNS chaos settings of an experiment
+type DNSSpec struct {
+	Mode    string   `yaml:"mode"`
+	Domains []string `yaml:"domains"`
+	Answer  string   `yaml:"answer"`
+}
+
+// DiskSpec holds the disk-fill settings of an experiment
+type DiskSpec struct {
+	Path        string `yaml:"path"`
+	FillPercent int    `yaml:"fillPercent"`
+}
+
+// TimeSpec holds the time-skew settings of an experiment
+type TimeSpec struct {
+	Offset      string `yaml:"offset"`
+	HelperImage string `yaml:"helperImage"`
+}
+
+// ProcessSpec selects the processes of the process chaos types in an experiment
+type ProcessSpec struct {
+	Match        string `yaml:"match"`
+	Signal       string `yaml:"signal"`
+	AllowPID1    *bool  `yaml:"allowPID1"`
+	Interval     string `yaml:"interval"`
+	MaxProcesses int    `yaml:"maxProcesses"`
+}
+
+// WorkloadSpec holds the workload scale settings of an experiment
+type WorkloadSpec struct {
+	ScalePercent int `yaml:"scalePercent"`
+}
+
+// SafetySpec holds the safety limits of an experiment
+type SafetySpec struct {
+	DryRun         *bool    `yaml:"dryRun"`
+	Strict         *bool    `yaml:"strict"`
+	IgnorePDB      *bool    `yaml:"ignorePDB"`
+	MinAvailable   string   `yaml:"minAvailable"`
+	MaxTargets     int      `yaml:"maxTargets"`
+	MaxDuration    string   `yaml:"maxDuration"`
+	MaxIntensity   int      `yaml:"maxIntensity"`
+	DenyNamespaces []string `yaml:"denyNamespaces"`
+}
+
+// experimentFile is the multi-experiment form of an experiment file
+type experimentFile struct {
+	Experiments []ExperimentSpec `yaml:"experiments"`
+}
+
+// lineError is a problem found at a line of an experiment file
+type lineError struct {
+	line int
+	msg  string
+}
+
+// LoadExperimentFile reads one or more experiments from a YAML or JSON file.
+// A file holds a single experiment, a list under "experiments", or several
+// YAML documents. Every experiment starts from base, gets the file values
+// applied, then override (which applies flags set on the command line), and
+// is validated last. All problems are reported with their line numbers.
+func LoadExperimentFile(path string, base Experiment, override func(*Experiment)) ([]*Experiment, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open experiment file: %v", err)
+	}
+	defer file.Close()
+
+	var experiments []*Experiment
+	var problems []lineError
+	decoder := yaml.NewDecoder(file)
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+
+		if len(document.Content) > 0 && mappingValue(document.Content[0], "experiments") != nil {
+			problems = append(problems, unknownFields(document.Content[0], reflect.TypeOf(experimentFile{}))...)
+		}
+		for _, node := range experimentNodes(&document) {
+			experiment, nodeProblems := loadExperiment(node, base, override)
+			problems = append(problems, nodeProblems...)
+			if experiment != nil {
+				experiments = append(experiments, experiment)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		var messages []string
+		for _, problem := range problems {
+			messages = append(messages, fmt.Sprintf("%s:%d: %s", path, problem.line, problem.msg))
+		}
+		return nil, errors.New(strings.Join(messages, "\n"))
+	}
+	if len(experiments) == 0 {
+		return nil, fmt.Errorf("%s: no experiments found", path)
+	}
+	return experiments, nil
+}
+
+// experimentNodes returns the experiment mappings of a YAML document
+func experimentNodes(document *yaml.Node) []*yaml.Node {
+	if len(document.Content) == 0 {
+		return nil
+	}
+	root := document.Content[0]
+	if list := mappingValue(root, "experiments"); list != nil {
+		if list.Kind != yaml.SequenceNode {
+			return []*yaml.Node{list}
+		}
+		return list.Content
+	}
+	return []*yaml.Node{root}
+}
+
+// loadExperiment decodes and validates a single experiment node
+func loadExperiment(node *yaml.Node, base Experiment, override func(*Experiment)) (*Experiment, []lineError) {
+	var spec ExperimentSpec
+	if problems := decodeNode(node, &spec); len(problems) > 0 {
+		return nil, problems
+	}
+	return buildExperiment(node, spec, base, override)
+}
+
+// decodeNode decodes a mapping node into out, rejecting fields that out does not have
+func decodeNode(node *yaml.Node, out interface{}) []lineError {
+	if node.Kind != yaml.MappingNode {
+		return []lineError{{node.Line, "expected a mapping"}}
+	}
+
+	problems := unknownFields(node, reflect.TypeOf(out).Elem())
+	if err := node.Decode(out); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return append(problems, lineError{node.Line, err.Error()})
+		}
+		// Type errors read "line N: cannot unmarshal ..."
+		for _, message := range typeErr.Errors {
+			line := node.Line
+			if _, err := fmt.Sscanf(message, "line %d:", &line); err == nil {
+				message = strings.TrimSpace(message[strings.Index(message, ":")+1:])
+			}
+			problems = append(problems, lineError{line, message})
+		}
+	}
+	return problems
+}
+
+// buildExperiment applies a decoded spec on top of base and validates the result
+func buildExperiment(node *yaml.Node, spec ExperimentSpec, base Experiment, override func(*Experiment)) (*Experiment, []lineError) {
+	experiment := base
+	experiment.Chaos.NamespaceSelection.Names = append([]string{}, base.Chaos.NamespaceSelection.Names...)
+	experiment.Chaos.NamespaceSelection.Deny = append([]string{}, base.Chaos.NamespaceSelection.Deny...)
+	experiment.Name = fmt.Sprintf("experiment at line %d", node.Line)
+	problems := applyExperimentSpec(&experiment, spec, node)
+	if override != nil {
+		override(&experiment)
+	}
+
+	for _, problem := range validateExperiment(&experiment, spec) {
+		problems = append(problems, lineError{valueLine(node, problem.field...), problem.msg})
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return &experiment, nil
+}
+
+// applyExperimentSpec copies the values set in the file onto the experiment
+func applyExperimentSpec(experiment *Experiment, spec ExperimentSpec, node *yaml.Node) []lineError {
+	var problems []lineError
+	parseDuration := func(value string, field ...string) time.Duration {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			problems = append(problems, lineError{valueLine(node, field...), fmt.Sprintf("invalid %s %q", strings.Join(field, "."), value)})
+		}
+		return parsed
+	}
+
+	if spec.Name != "" {
+		experiment.Name = spec.Name
+	}
+	if spec.ChaosType != "" {
+		experiment.Chaos.Type = ChaosType(spec.ChaosType)
+	}
+	if spec.Intensity != nil {
+		experiment.Chaos.Intensity = *spec.Intensity
+	}
+	if spec.Duration != "" {
+		experiment.Chaos.Duration = parseDuration(spec.Duration, "duration")
+	}
+	if spec.Probability != nil {
+		experiment.Probability = *spec.Probability
+	}
+	if spec.Schedule != "" {
+		experiment.Schedule = spec.Schedule
+	}
+
+	// Targets
+	targets := spec.Targets
+	if targets.Namespace != "" {
+		experiment.Chaos.Namespace = targets.Namespace
+	}
+	if len(targets.Namespaces) > 0 || targets.NamespaceSelector != "" || targets.AllNamespaces {
+		selection, err := parseNamespaceSelection(strings.Join(targets.Namespaces, ","), targets.NamespaceSelector, "")
+		if err != nil {
+			problems = append(problems, lineError{valueLine(node, "targets", "namespaceSelector"), err.Error()})
+		}
+		if targets.AllNamespaces {
+			selection.Names = []string{"*"}
+		}
+		experiment.Chaos.NamespaceSelection.Names = selection.Names
+		experiment.Chaos.NamespaceSelection.Selector = selection.Selector
+	}
+	if targets.Labels != "" {
+		selector, err := parseLabelSelector(targets.Labels)
+		if err != nil {
+			problems = append(problems, lineError{valueLine(node, "targets", "labels"), err.Error()})
+		}
+		experiment.Chaos.LabelSelector = selector
+	}
+	if targets.FieldSelector != "" {
+		experiment.Chaos.FieldSelector = targets.FieldSelector
+	}
+	if targets.ReadyOnly != nil {
+		experiment.Chaos.ReadyOnly = *targets.ReadyOnly
+	}
+	if targets.Count != nil {
+		experiment.Chaos.TargetCount = *targets.Count
+	}
+	if targets.Percent != 0 {
+		experiment.Chaos.TargetPercent = targets.Percent
+	}
+	if targets.Min != 0 {
+		experiment.Chaos.TargetMin = targets.Min
+	}
+	if targets.Max != 0 {
+		experiment.Chaos.TargetMax = targets.Max
+	}
+	if targets.WeightBy != "" {
+		weighting, err := targeting.ParseWeighting(targets.WeightBy)
+		if err != nil {
+			problems = append(problems, lineError{valueLine(node, "targets", "weightBy"), err.Error()})
+		}
+		experiment.Chaos.Selection.Weighting = weighting
+	}
+	if targets.Selection != "" {
+		experiment.Chaos.Selection.Strategy = targeting.Strategy(targets.Selection)
+	}
+	if targets.MaxPerOwner != nil {
+		experiment.Chaos.Selection.MaxPerOwner = *targets.MaxPerOwner
+	}
+	if targets.OwnerKind != "" {
+		experiment.Chaos.Selection.OwnerKind = targets.OwnerKind
+	}
+
+	// Network
+	if spec.Network.Interface != "" {
+		experiment.Chaos.Network.Interface = spec.Network.Interface
+	}
+	if spec.Network.Latency != "" {
+		experiment.Chaos.Network.Latency = parseDuration(spec.Network.Latency, "network", "latency")
+	}
+	if spec.Network.Jitter != "" {
+		experiment.Chaos.Network.Jitter = parseDuration(spec.Network.Jitter, "network", "jitter")
+	}
+	if spec.Network.Loss != nil {
+		experiment.Chaos.Network.Loss = *spec.Network.Loss
+	}
+	if spec.Network.Corrupt != nil {
+		experiment.Chaos.Network.Corrupt = *spec.Network.Corrupt
+	}
+	if spec.Network.Duplicate != nil {
+		experiment.Chaos.Network.Duplicate = *spec.Network.Duplicate
+	}
+	if spec.Network.Reorder != nil {
+		experiment.Chaos.Network.Reorder = *spec.Network.Reorder
+	}
+	if spec.Network.Rate != "" {
+		experiment.Chaos.Network.Rate = spec.Network.Rate
+	}
+	if len(spec.Network.Destinations) > 0 {
+		experiment.Chaos.Network.Destinations = spec.Network.Destinations
+	}
+	if len(spec.Network.Ports) > 0 {
+		experiment.Chaos.Network.Ports = spec.Network.Ports
+	}
+	if spec.Network.PeerLabels != "" {
+		selector, err := parseLabelSelector(spec.Network.PeerLabels)
+		if err != nil {
+			problems = append(problems, lineError{valueLine(node, "network", "peerLabels"), err.Error()})
+		}
+		experiment.Chaos.Network.PeerSelector = selector
+	}
+
+	// Node
+	if spec.Node.Labels != "" {
+		selector, err := parseLabelSelector(spec.Node.Labels)
+		if err != nil {
+			problems = append(problems, lineError{valueLine(node, "node", "labels"), err.Error()})
+		}
+		experiment.Chaos.Node.LabelSelector = selector
+	}
+	if len(spec.Node.Zones) > 0 {
+		experiment.Chaos.Node.Zones = spec.Node.Zones
+	}
+
+	// DNS
+	if spec.DNS.Mode != "" {
+		experiment.Chaos.DNS.Mode = spec.DNS.Mode
+	}
+	if len(spec.DNS.Domains) > 0 {
+		experiment.Chaos.DNS.Domains = spec.DNS.Domains
+	}
+	if spec.DNS.Answer != "" {
+		experiment.Chaos.DNS.Answer = spec.DNS.Answer
+	}
+
+	// Disk
+	if spec.Disk.Path != "" {
+		experiment.Chaos.Disk.Path = spec.Disk.Path
+	}
+	if spec.Disk.FillPercent != 0 {
+		experiment.Chaos.Disk.FillPercent = spec.Disk.FillPercent
+	}
+
+	// Time
+	if spec.Time.Offset != "" {
+		experiment.Chaos.Time.Offset = parseDuration(spec.Time.Offset, "time", "offset")
+	}
+	if spec.Time.HelperImage != "" {
+		experiment.Chaos.Time.HelperImage = spec.Time.HelperImage
+	}
+
+	if spec.Container != "" {
+		experiment.Chaos.Container = spec.Container
+	}
+
+	// Process
+	if spec.Process.Match != "" {
+		experiment.Chaos.Process.Match = spec.Process.Match
+	}
+	if spec.Process.Signal != "" {
+		experiment.Chaos.Process.Signal = parseSignal(spec.Process.Signal)
+	}
+	if spec.Process.AllowPID1 != nil {
+		experiment.Chaos.Process.AllowPID1 = *spec.Process.AllowPID1
+	}
+	if spec.Process.Interval != "" {
+		experiment.Chaos.Process.Interval = parseDuration(spec.Process.Interval, "process", "interval")
+	}
+	if spec.Process.MaxProcesses != 0 {
+		experiment.Chaos.Process.MaxProcesses = spec.Process.MaxProcesses
+	}
+
+	// Workload
+	if spec.Workload.ScalePercent != 0 {
+		experiment.Chaos.Workload.ScalePercent = spec.Workload.ScalePercent
+	}
+
+	// Safety
+	if spec.Safety.DryRun != nil {
+		experiment.Chaos.DryRun = *spec.Safety.DryRun
+	}
+	if spec.Safety.Strict != nil {
+		experiment.Chaos.Strict = *spec.Safety.Strict
+	}
+	if spec.Safety.IgnorePDB != nil {
+		experiment.Chaos.IgnorePDB = *spec.Safety.IgnorePDB
+	}
+	if spec.Safety.MinAvailable != "" {
+		minAvailable, err := parseMinAvailable(spec.Safety.MinAvailable)
+		if err != nil {
+			problems = append(problems, lineError{valueLine(node, "safety", "minAvailable"), err.Error()})
+		}
+		experiment.Chaos.MinAvailable = minAvailable
+	}
+	experiment.Safety.MaxTargets = spec.Safety.MaxTargets
+	experiment.Safety.MaxIntensity = spec.Safety.MaxIntensity
+	if spec.Safety.MaxDuration != "" {
+		experiment.Safety.MaxDuration = parseDuration(spec.Safety.MaxDuration, "safety", "maxDuration")
+	}
+	experiment.Chaos.NamespaceSelection.Deny = append(experiment.Chaos.NamespaceSelection.Deny, spec.Safety.DenyNamespaces...)
+
+	// Steady state
+	if spec.SteadyState != nil {
+		steadyState, steadyStateProblems := parseSteadyState(*spec.SteadyState)
+		for _, problem := range steadyStateProblems {
+			problems = append(problems, lineError{valueLine(node, problem.field...), problem.msg})
+		}
+		experiment.SteadyState = steadyState
+	}
+
+	// Abort conditions
+	if spec.Abort != nil {
+		abort := spec.Abort
+		if abort.MaxRestarts != nil {
+			experiment.Abort.MaxRestarts = *abort.MaxRestarts
+		}
+		if abort.MinReadyPercent != nil {
+			experiment.Abort.MinReadyPercent = *abort.MinReadyPercent
+		}
+		if abort.OnPodFailure != nil {
+			experiment.Abort.OnPodFailure = *abort.OnPodFailure
+		}
+		if abort.OnProbeFailure != nil {
+			experiment.Abort.OnProbeFailure = *abort.OnProbeFailure
+		}
+		if abort.Interval != "" {
+			experiment.Abort.Interval = parseDuration(abort.Interval, "abort", "interval")
+		}
+	}
+
+	return problems
+}
+
+// experimentProblem is a validation problem for a field of an experiment
+type experimentProblem struct {
+	field []string
+	msg   string
+}
+
+// validateExperiment checks an experiment. When spec is given, it is used to
+// reject settings that only make sense together with others.
+func validateExperiment(experiment *Experiment, spec ExperimentSpec) []experimentProblem {
+	var problems []experimentProblem
+	add := func(msg string, field ...string) {
+		problems = append(problems, experimentProblem{field, msg})
+	}
+
+	chaos := experiment.Chaos
+	if chaos.Intensity < 1 || chaos.Intensity > 10 {
+		add(fmt.Sprintf("intensity must be between 1 and 10, got %d", chaos.Intensity), "intensity")
+	}
+	if chaos.Duration <= 0 {
+		add("duration must be greater than zero", "duration")
+	}
+	if chaos.TargetCount < 1 {
+		add(fmt.Sprintf("target count must be at least 1, got %d", chaos.TargetCount), "targets", "count")
+	}
+	if chaos.TargetPercent < 0 || chaos.TargetPercent > 100 {
+		add(fmt.Sprintf("targets.percent must be between 0 and 100, got %d", chaos.TargetPercent), "targets", "percent")
+	}
+	if chaos.TargetMin < 0 {
+		add(fmt.Sprintf("targets.min must not be negative, got %d", chaos.TargetMin), "targets", "min")
+	}
+	if chaos.TargetMax < 0 || (chaos.TargetMax > 0 && chaos.TargetMax < chaos.TargetMin) {
+		add(fmt.Sprintf("targets.max must be at least targets.min, got %d", chaos.TargetMax), "targets", "max")
+	}
+	if err := chaos.Selection.Validate(); err != nil {
+		add(err.Error(), "targets")
+	}
+
+	injector, err := LookupInjector(chaos.Type)
+	if err != nil {
+		add(err.Error(), "chaosType")
+	} else if len(problems) == 0 {
+		// The shared checks above point at the right line, so only run the
+		// chaos type specific checks once those pass
+		if err := injector.Validate(chaos); err != nil {
+			add(err.Error(), "chaosType")
+		}
+	}
+
+	if experiment.Schedule != "" {
+		if _, err := cron.ParseStandard(experiment.Schedule); err != nil {
+			add(fmt.Sprintf("invalid schedule %q: %v", experiment.Schedule, err), "schedule")
+		}
+	} else if spec.Probability != nil {
+		add("probability is only used together with a schedule", "probability")
+	}
+	if experiment.Probability < 0 || experiment.Probability > 1 {
+		add(fmt.Sprintf("probability must be between 0.0 and 1.0, got %v", experiment.Probability), "probability")
+	}
+
+	abort := experiment.Abort
+	if abort.MinReadyPercent < 0 || abort.MinReadyPercent > 100 {
+		add(fmt.Sprintf("abort.minReadyPercent must be between 0 and 100, got %d", abort.MinReadyPercent), "abort", "minReadyPercent")
+	}
+	if abort.Interval <= 0 {
+		add("abort.interval must be greater than zero", "abort", "interval")
+	}
+	if abort.OnProbeFailure && !experiment.SteadyState.probesIn(ProbePhaseDuring) {
+		add("abort.onProbeFailure needs a steady-state probe that runs during injection", "abort", "onProbeFailure")
+	}
+
+	safety := experiment.Safety
+	if safety.MaxTargets > 0 && chaos.TargetPercent > 0 {
+		// The number of targets is only known once the candidates are listed
+		if chaos.TargetMax == 0 || chaos.TargetMax > safety.MaxTargets {
+			add(fmt.Sprintf("targets.percent needs a targets.max within safety.maxTargets %d", safety.MaxTargets), "targets", "percent")
+		}
+	} else if safety.MaxTargets > 0 && chaos.TargetCount > safety.MaxTargets {
+		add(fmt.Sprintf("target count %d exceeds safety.maxTargets %d", chaos.TargetCount, safety.MaxTargets), "targets", "count")
+	}
+	if safety.MaxDuration > 0 && chaos.Duration > safety.MaxDuration {
+		add(fmt.Sprintf("duration %s exceeds safety.maxDuration %s", chaos.Duration, safety.MaxDuration), "duration")
+	}
+	if safety.MaxIntensity > 0 && chaos.Intensity > safety.MaxIntensity {
+		add(fmt.Sprintf("intensity %d exceeds safety.maxIntensity %d", chaos.Intensity, safety.MaxIntensity), "intensity")
+	}
+	return problems
+}
+
+// unknownFields reports mapping keys that have no matching field in the struct type
+func unknownFields(node *yaml.Node, structType reflect.Type) []lineError {
+	if node.Kind == yaml.SequenceNode && structType.Kind() == reflect.Slice {
+		var problems []lineError
+		for _, item := range node.Content {
+			problems = append(problems, unknownFields(item, structType.Elem())...)
+		}
+		return problems
+	}
+	if node.Kind != yaml.MappingNode || structType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := map[string]reflect.Type{}
+	collectYAMLFields(structType, fields)
+
+	var problems []lineError
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		fieldType, ok := fields[key.Value]
+		if !ok {
+			problems = append(problems, lineError{key.Line, fmt.Sprintf("unknown field %q", key.Value)})
+			continue
+		}
+		problems = append(problems, unknownFields(value, fieldType)...)
+	}
+	return problems
+}
+
+// collectYAMLFields maps the yaml keys of a struct type, including inlined structs, to their types
+func collectYAMLFields(structType reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		tag := strings.Split(field.Tag.Get("yaml"), ",")
+		if len(tag) > 1 && tag[1] == "inline" {
+			collectYAMLFields(fieldType, fields)
+			continue
+		}
+		fields[tag[0]] = fieldType
+	}
+}
+
+// mappingValue returns the value stored under key in a mapping node, or the
+// item at index key of a sequence node; nil when there is none
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node != nil && node.Kind == yaml.SequenceNode {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= len(node.Content) {
+			return nil
+		}
+		return node.Content[index]
+	}
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// valueLine returns the line of the value found by following the keys, falling
+// back to the closest enclosing node that exists
+func valueLine(node *yaml.Node, keys ...string) int {
+	line := node.Line
+	for _, key := range keys {
+		node = mappingValue(node, key)
+		if node == nil {
+			break
+		}
+		line = node.Line
+	}
+	return line
+}
+
+// runExperiment runs the experiment once, or starts its cron trigger when it has
+// a schedule. It reports whether a cron trigger was started.
+func runExperiment(ctx context.Context, env ChaosEnv, experiment *Experiment) (bool, error) {
+	injector, err := LookupInjector(experiment.Chaos.Type)
+	if err != nil {
+		return false, err
+	}
+
+	if experiment.Schedule != "" {
+		StartCronTrigger(ctx, env, CronTriggerConfig{
+			Schedule:    experiment.Schedule,
+			ChaosType:   experiment.Chaos.Type,
+			Probability: experiment.Probability,
+			MaxDuration: experiment.Chaos.Duration,
+			Template:    experiment.Chaos,
+			Randomize:   experiment.RandomizeTriggers,
+			Safety:      experiment.Safety,
+			SteadyState: experiment.SteadyState,
+			Abort:       experiment.Abort,
+		})
+		return true, nil
+	}
+
+	fmt.Printf("🧪 Running experiment: %s (%s)\n", experiment.Name, experiment.Chaos.Type)
+	return false, runGuarded(ctx, env, experiment.Name, injector, experiment.Chaos, experiment.SteadyState, experiment.Abort)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// ChaosEnv holds the Kubernetes clients shared by every chaos injector
+type ChaosEnv struct {
+	RestConfig    *rest.Config
+	Clientset     *kubernetes.Clientset
+	RevertTimeout time.Duration // Time an abort gives the rollback, the shutdown grace period
+}
+
+// runIDLabel labels the helper pods created by a run with its run ID, so the
+// rollback of one run leaves the helpers of other runs alone
+const runIDLabel = "kubechaos.io/run"
+
+// runCounter keeps the run IDs of runs started in the same instant apart
+var runCounter int64
+
+// newRunID returns an ID that tells the faults of one injection from the others
+func newRunID() string {
+	return fmt.Sprintf("%s-%d", strconv.FormatInt(time.Now().Unix(), 36), atomic.AddInt64(&runCounter, 1))
+}
+
+// runAnnotation is the annotation holding the run ID next to a fault marker annotation
+func runAnnotation(marker string) string {
+	return marker + "-run"
+}
+
+// ownedByRun reports whether the fault marker in the annotations belongs to the
+// run; an empty run ID (-cleanup) owns every marker
+func ownedByRun(annotations map[string]string, marker, runID string) bool {
+	return runID == "" || annotations[runAnnotation(marker)] == runID
+}
+
+// runSelector narrows a helper pod label selector to the helpers of the run
+func runSelector(selector, runID string) string {
+	if runID == "" {
+		return selector
+	}
+	return fmt.Sprintf("%s,%s=%s", selector, runIDLabel, runID)
+}
+
+// ChaosInjector is implemented by every chaos type that kubechaos can apply
+type ChaosInjector interface {
+	// Name returns the chaos type handled by the injector
+	Name() ChaosType
+	// Validate checks the configuration before anything is injected
+	Validate(config ChaosConfig) error
+	// Inject applies the fault to the targets selected by the configuration.
+	// It stops early when ctx is cancelled.
+	Inject(ctx context.Context, env ChaosEnv, config ChaosConfig) error
+	// Revert undoes whatever Inject left behind; it must be safe to call more than once
+	Revert(ctx context.Context, env ChaosEnv, config ChaosConfig) error
+}
+
+// registeredInjector is an entry of the chaos registry
+type registeredInjector struct {
+	injector    ChaosInjector
+	description string
+}
+
+// chaosRegistry maps every supported chaos type to its injector
+var chaosRegistry = map[ChaosType]registeredInjector{}
+
+// RegisterInjector makes a chaos type available to the CLI, the cron trigger and the help listing
+func RegisterInjector(injector ChaosInjector, description string) {
+	name := injector.Name()
+	if _, exists := chaosRegistry[name]; exists {
+		panic(fmt.Sprintf("chaos type %s registered twice", name))
+	}
+	chaosRegistry[name] = registeredInjector{injector: injector, description: description}
+}
+
+// LookupInjector returns the injector registered for the chaos type
+func LookupInjector(chaosType ChaosType) (ChaosInjector, error) {
+	entry, ok := chaosRegistry[chaosType]
+	if !ok {
+		return nil, fmt.Errorf("unknown chaos type %q (available: %s)", chaosType, strings.Join(chaosTypeNames(), ", "))
+	}
+	return entry.injector, nil
+}
+
+// RegisteredChaosTypes returns all registered chaos types in alphabetical order
+func RegisteredChaosTypes() []ChaosType {
+	chaosTypes := make([]ChaosType, 0, len(chaosRegistry))
+	for chaosType := range chaosRegistry {
+		chaosTypes = append(chaosTypes, chaosType)
+	}
+	sort.Slice(chaosTypes, func(i, j int) bool { return chaosTypes[i] < chaosTypes[j] })
+	return chaosTypes
+}
+
+// chaosTypeNames returns the registered chaos types as strings
+func chaosTypeNames() []string {
+	var names []string
+	for _, chaosType := range RegisteredChaosTypes() {
+		names = append(names, string(chaosType))
+	}
+	return names
+}
+
+// PrintChaosTypes prints every registered chaos type with its description
+func PrintChaosTypes() {
+	for _, chaosType := range RegisteredChaosTypes() {
+		fmt.Printf("  %-24s %s\n", chaosType, chaosRegistry[chaosType].description)
+	}
+}
+
+// validateChaosConfig checks the settings shared by all chaos types
+func validateChaosConfig(config ChaosConfig) error {
+	if config.Namespace == "" {
+		return fmt.Errorf("namespace must not be empty")
+	}
+	if config.Intensity < 1 || config.Intensity > 10 {
+		return fmt.Errorf("intensity must be between 1 and 10, got %d", config.Intensity)
+	}
+	if config.Duration <= 0 {
+		return fmt.Errorf("duration must be greater than zero")
+	}
+	if config.TargetCount < 1 {
+		return fmt.Errorf("target count must be at least 1, got %d", config.TargetCount)
+	}
+	if config.TargetPercent < 0 || config.TargetPercent > 100 {
+		return fmt.Errorf("target percentage must be between 0 and 100, got %d", config.TargetPercent)
+	}
+	if config.TargetMin < 0 || config.TargetMax < 0 {
+		return fmt.Errorf("target bounds must not be negative")
+	}
+	if config.TargetMax > 0 && config.TargetMax < config.TargetMin {
+		return fmt.Errorf("target maximum %d is below the minimum %d", config.TargetMax, config.TargetMin)
+	}
+	return config.Selection.Validate()
+}
+
+// funcInjector adapts the Apply* functions to the ChaosInjector interface
+type funcInjector struct {
+	name     ChaosType
+	validate func(config ChaosConfig) error
+	inject   func(ctx context.Context, env ChaosEnv, config ChaosConfig) error
+	revert   func(ctx context.Context, env ChaosEnv, config ChaosConfig) error
+}
+
+func (f *funcInjector) Name() ChaosType {
+	return f.name
+}
+
+func (f *funcInjector) Validate(config ChaosConfig) error {
+	if err := validateChaosConfig(config); err != nil {
+		return err
+	}
+	if f.validate != nil {
+		return f.validate(config)
+	}
+	return nil
+}
+
+func (f *funcInjector) Inject(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+	return f.inject(ctx, env, config)
+}
+
+func (f *funcInjector) Revert(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+	if f.revert == nil {
+		return nil
+	}
+	return f.revert(ctx, env, config)
+}
@@ -1,16 +1,15 @@
 package main
 
 import (
-	"context"
+	"errors"
 	"flag"
 	"fmt"
-	"math/rand"
 	"os"
 	"strings"
 	"time"
 
-	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/iamkrati22/kubechaos/targeting"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -18,20 +17,70 @@ import (
 func main() {
 	// Parse command line flags
 	var (
-		namespace    = flag.String("namespace", "default", "Namespace to operate on")
-		labelFilter  = flag.String("labels", "", "Label selector (e.g., 'app=nginx,env=prod')")
-		createPods   = flag.Bool("create", false, "Create test pods before chaos")
-		podCount     = flag.Int("count", 3, "Number of test pods to create")
-		deleteCount  = flag.Int("delete-count", 1, "Number of pods to delete (default: 1)")
-		dryRun       = flag.Bool("dry-run", false, "Show what would be deleted without actually deleting")
-		cleanup      = flag.Bool("cleanup", false, "Clean up test pods created by chaos monkey")
-		chaosType    = flag.String("chaos-type", "pod-delete", "Type of chaos: pod-delete, cpu-stress, memory-stress, in-pod-cpu-stress, in-pod-memory-stress, in-pod-mixed-stress, kill-process, corrupt-memory")
-		intensity    = flag.Int("intensity", 5, "Chaos intensity (1-10 scale)")
-		duration     = flag.String("duration", "30s", "Duration of chaos (e.g., 30s, 2m, 1h)")
-		cronSchedule = flag.String("cron", "", "Cron schedule for periodic chaos (e.g., '*/5 * * * *')")
-		probability  = flag.Float64("probability", 0.5, "Probability of chaos trigger (0.0-1.0)")
-		help         = flag.Bool("help", false, "Show help message")
-		version      = flag.Bool("version", false, "Show version information")
+		namespace       = flag.String("namespace", "default", "Namespace to operate on")
+		namespaces      = flag.String("namespaces", "", "Comma-separated namespaces or globs to target (e.g., 'payments,team-*'); overrides -namespace")
+		namespaceFilter = flag.String("namespace-selector", "", "Label selector choosing target namespaces (e.g., 'team=payments')")
+		allNamespaces   = flag.Bool("all-namespaces", false, "Target pods in every namespace (system namespaces are always excluded)")
+		denyNamespaces  = flag.String("deny-namespaces", "", "Additional namespaces or globs that must never be targeted (kube-system, kube-public and kube-node-lease are always denied)")
+		labelFilter     = flag.String("labels", "", "Label selector, full Kubernetes syntax (e.g., 'app=nginx,env in (staging,qa),!canary')")
+		createPods      = flag.Bool("create", false, "Create test pods before chaos")
+		podCount        = flag.Int("count", 3, "Number of test pods to create")
+		deleteCount     = flag.Int("delete-count", 1, "Number of pods to delete (default: 1)")
+		targetPercent   = flag.Int("target-percent", 0, "Target this percentage of the matching pods instead of -delete-count (1-100)")
+		targetMin       = flag.Int("target-min", 0, "Minimum number of targets for -target-percent")
+		targetMax       = flag.Int("target-max", 0, "Maximum number of targets for -target-percent (0 is unlimited)")
+		fieldFilter     = flag.String("field-selector", "", "Field selector for target pods (e.g., 'spec.nodeName=node-1')")
+		readyOnly       = flag.Bool("ready-only", false, "Only target pods that are Ready")
+		selection       = flag.String("selection", "random", "How victims are picked: random, or spread across owners")
+		maxPerOwner     = flag.Int("max-per-owner", 0, "Pick at most this many pods per Deployment/StatefulSet/DaemonSet/Job (0 is unlimited)")
+		weightBy        = flag.String("weight-by", "", "Make some pods likelier targets: age, restarts, node:<name>=<weight>,... or annotation:<key>")
+		ownerKind       = flag.String("owner-kind", "", "Only target pods owned by this kind: "+strings.Join(targeting.OwnerKinds, ", "))
+		dryRun          = flag.Bool("dry-run", false, "Show what would be deleted without actually deleting")
+		strict          = flag.Bool("strict", false, "Only target pods annotated with kubechaos.io/enabled=true")
+		ignorePDB       = flag.Bool("ignore-pdb", false, "Delete pods directly instead of evicting them, bypassing PodDisruptionBudgets")
+		minAvailable    = flag.String("min-available", "", "Ready pods to keep per ReplicaSet/StatefulSet for pod-delete, as N or X% (e.g., '2' or '50%')")
+		cleanup         = flag.Bool("cleanup", false, "Clean up test pods created by chaos monkey")
+		chaosType       = flag.String("chaos-type", "pod-delete", "Type of chaos: "+strings.Join(chaosTypeNames(), ", "))
+		intensity       = flag.Int("intensity", 5, "Chaos intensity (1-10 scale)")
+		duration        = flag.String("duration", "30s", "Duration of chaos (e.g., 30s, 2m, 1h)")
+		latency         = flag.String("latency", "100ms", "Delay added to pod egress traffic for network-latency chaos")
+		jitter          = flag.String("jitter", "10ms", "Random variation of the added delay for network-latency chaos")
+		loss            = flag.Float64("loss", 10, "Percentage of packets dropped for network-loss chaos")
+		corrupt         = flag.Float64("corrupt", 5, "Percentage of packets corrupted for network-corrupt chaos")
+		duplicate       = flag.Float64("duplicate", 5, "Percentage of packets duplicated for network-duplicate chaos")
+		reorder         = flag.Float64("reorder", 25, "Percentage of packets reordered for network-reorder chaos (uses -latency)")
+		rate            = flag.String("rate", "1mbit", "Egress rate limit for network-bandwidth chaos (e.g., 1mbit, 500kbps)")
+		destinations    = flag.String("destinations", "", "Comma-separated CIDRs the network fault is limited to (e.g., '10.0.0.0/8')")
+		ports           = flag.String("ports", "", "Comma-separated destination ports the network fault is limited to (e.g., '5432,6379')")
+		peerLabels      = flag.String("peer-labels", "", "Label selector of the pods cut off from the targets by network-partition chaos")
+		netInterface    = flag.String("interface", "eth0", "Network interface inside the pod used for network chaos")
+		dnsMode         = flag.String("dns-mode", DNSModeFail, "How dns-failure chaos breaks lookups: fail, timeout or wrong")
+		dnsDomains      = flag.String("dns-domains", "", "Comma-separated domains broken by dns-failure chaos, with their subdomains in fail/timeout mode and exact names only in wrong mode; empty breaks every lookup")
+		dnsAnswer       = flag.String("dns-answer", "192.0.2.1", "IP address the domains resolve to with -dns-mode=wrong")
+		fillPath        = flag.String("fill-path", "/tmp", "Path inside the container whose filesystem disk-fill chaos fills (emptyDir, PVC or root filesystem)")
+		fillPercent     = flag.Int("fill-percent", 90, "Filesystem usage disk-fill chaos fills up to (1-100)")
+		timeOffset      = flag.String("time-offset", "1h", "Shift of the container clock for time-skew chaos (e.g., 72h, -30m)")
+		timeHelperImage = flag.String("time-helper-image", "ghcr.io/chaos-mesh/chaos-daemon:v2.6.3", "Image providing watchmaker, run as an ephemeral container by time-skew chaos")
+		processMatch    = flag.String("process", "", "Regex matched against process command lines for pod-freeze and kill-process (e.g., 'java|node'); empty selects every process of the container")
+		killSignal      = flag.String("signal", "KILL", "Signal sent by kill-process chaos: "+strings.Join(killSignals, ", "))
+		allowPID1       = flag.Bool("allow-pid1", false, "Let kill-process chaos signal the container's PID 1")
+		killInterval    = flag.String("kill-interval", "0s", "Repeat kill-process chaos at this interval for the duration (0 signals once)")
+		maxProcesses    = flag.Int("max-processes", 5, "Most processes kill-process chaos signals per pod and round")
+		containerName   = flag.String("container", "", "Container restarted by container-restart chaos (defaults to the first container of the pod)")
+		scalePercent    = flag.Int("scale-percent", 50, "Percentage of the replicas removed by scale-down chaos (1-100)")
+		nodeLabels      = flag.String("node-labels", "", "Label selector for the target nodes of node chaos (e.g., 'node-role.kubernetes.io/worker')")
+		zones           = flag.String("zones", "", "Comma-separated zones (topology.kubernetes.io/zone) to pick target nodes from")
+		abortRestarts   = flag.Int("abort-max-restarts", -1, "Abort and roll back when the targets restart more than this many times (-1 disables)")
+		abortMinReady   = flag.Int("abort-min-ready", 0, "Abort and roll back when fewer than this percentage of the targets are Ready (0 disables)")
+		abortPodFailure = flag.Bool("abort-on-pod-failure", false, "Abort and roll back when a target pod enters the Failed phase")
+		shutdownGrace   = flag.String("shutdown-grace", "30s", "How long to wait for active faults to be reverted on SIGINT/SIGTERM")
+		experimentFile  = flag.String("f", "", "Run the experiments defined in a YAML/JSON file; flags given explicitly override file values")
+		scenarioFile    = flag.String("scenario", "", "Run the multi-step scenario defined in a YAML/JSON file")
+		cronSchedule    = flag.String("cron", "", "Cron schedule for periodic chaos (e.g., '*/5 * * * *')")
+		probability     = flag.Float64("probability", 0.5, "Probability of chaos trigger (0.0-1.0)")
+		seed            = flag.Int64("seed", 0, "Seed for victim selection, probability rolls and randomized parameters; replays a run on the same cluster state (0 picks one)")
+		help            = flag.Bool("help", false, "Show help message")
+		version         = flag.Bool("version", false, "Show version information")
 	)
 	flag.Parse()
 
@@ -46,28 +95,112 @@ func main() {
 		fmt.Println("  go run main.go [flags]")
 		fmt.Println("\nFlags:")
 		flag.PrintDefaults()
+		fmt.Println("\nChaos types:")
+		PrintChaosTypes()
 		fmt.Println("\nExamples:")
 		fmt.Println("  go run main.go                                    # Delete random pod in default namespace")
-		fmt.Println("  go run main.go -namespace=kube-system             # Delete random pod in kube-system")
+		fmt.Println("  go run main.go -namespace=staging                 # Delete random pod in staging")
+		fmt.Println("  go run main.go -namespaces='team-*' -dry-run      # Preview deletions across team-* namespaces")
+		fmt.Println("  go run main.go -namespace-selector='team=payments' # Target namespaces labelled team=payments")
 		fmt.Println("  go run main.go -labels='app=nginx'                # Delete random pod with app=nginx label")
+		fmt.Println("  go run main.go -labels='env in (staging,qa),!canary'  # Set-based label selector")
 		fmt.Println("  go run main.go -create -count=5                   # Create 5 test pods then delete one")
 		fmt.Println("  go run main.go -delete-count=3                    # Delete 3 random pods")
 		fmt.Println("  go run main.go -dry-run                           # Show what would be deleted")
+		fmt.Println("  go run main.go -delete-count=3 -min-available=50% # Keep half of every owner's pods ready")
+		fmt.Println("  go run main.go -delete-count=3 -selection=spread  # Delete 3 pods of 3 different owners")
+		fmt.Println("  go run main.go -target-percent=10 -target-max=5   # Delete 10% of the pods, at most 5")
+		fmt.Println("  go run main.go -weight-by=restarts                # Prefer pods that restarted before")
+		fmt.Println("  go run main.go -owner-kind=StatefulSet -max-per-owner=1  # One pod per StatefulSet")
+		fmt.Println("  go run main.go -strict                            # Only target pods annotated kubechaos.io/enabled=true")
+		fmt.Println("  go run main.go -ignore-pdb                        # Delete without honouring PodDisruptionBudgets")
 		fmt.Println("  go run main.go -cleanup                           # Clean up all test pods")
 		fmt.Println("  go run main.go -chaos-type=cpu-stress             # Apply CPU stress to pods")
 		fmt.Println("  go run main.go -chaos-type=memory-stress          # Apply memory stress to pods")
 		fmt.Println("  go run main.go -chaos-type=in-pod-cpu-stress      # Apply CPU stress inside pods")
 		fmt.Println("  go run main.go -chaos-type=in-pod-memory-stress   # Apply memory stress inside pods")
 		fmt.Println("  go run main.go -chaos-type=in-pod-mixed-stress    # Apply mixed stress inside pods")
+		fmt.Println("  go run main.go -chaos-type=in-pod-io-stress       # Apply disk I/O stress inside pods")
 		fmt.Println("  go run main.go -chaos-type=kill-process           # Kill random processes in pods")
+		fmt.Println("  go run main.go -chaos-type=kill-process -process=nginx -signal=HUP -kill-interval=10s  # Reload nginx repeatedly")
+		fmt.Println("  go run main.go -chaos-type=pod-freeze -process=java -duration=20s  # Simulate a long GC pause")
+		fmt.Println("  go run main.go -chaos-type=container-restart -container=sidecar  # Crash one container in place")
 		fmt.Println("  go run main.go -chaos-type=corrupt-memory         # Corrupt memory in pods")
+		fmt.Println("  go run main.go -chaos-type=network-latency        # Add latency to pod network traffic")
+		fmt.Println("  go run main.go -chaos-type=node-drain -zones=eu-west-1a  # Cordon and drain a node in one zone")
+		fmt.Println("  go run main.go -chaos-type=network-loss -loss=20 -ports=5432  # Drop 20% of the traffic to port 5432")
+		fmt.Println("  go run main.go -chaos-type=network-partition -labels='zone=a' -peer-labels='zone=b'  # Split brain")
+		fmt.Println("  go run main.go -chaos-type=dns-failure -dns-mode=timeout -dns-domains=db.example.com  # Slow lookups")
+		fmt.Println("  go run main.go -chaos-type=disk-fill -fill-path=/data -fill-percent=95  # Nearly fill a volume")
+		fmt.Println("  go run main.go -chaos-type=time-skew -time-offset=720h  # Move the clock 30 days ahead")
+		fmt.Println("  go run main.go -chaos-type=scale-to-zero -labels='app=api' -duration=2m  # Simulate a bad deploy")
 		fmt.Println("  go run main.go -cron='*/5 * * * *'               # Run chaos every 5 minutes")
+		fmt.Println("  go run main.go -f experiment.yaml                # Run experiments from a file")
+		fmt.Println("  go run main.go -f experiment.yaml -dry-run       # Run a file, overriding dry-run")
+		fmt.Println("  go run main.go -scenario=scenario.yaml           # Run a multi-step scenario")
+		fmt.Println("  go run main.go -chaos-type=in-pod-cpu-stress -abort-min-ready=50  # Stop when under 50% ready")
+		fmt.Println("  go run main.go -seed=1718000000 -dry-run         # Replay the victim selection of an earlier run")
 		fmt.Println("  go run main.go -intensity=8                      # High intensity chaos (1-10)")
 		return
 	}
 
-	// Initialize random seed
-	rand.Seed(time.Now().UnixNano())
+	// Validate the label selector before talking to the cluster
+	labelSelector, err := parseLabelSelector(*labelFilter)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	namespaceSelection, err := parseNamespaceSelection(*namespaces, *namespaceFilter, *denyNamespaces)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if *allNamespaces {
+		namespaceSelection.Names = []string{"*"}
+	}
+	podMinAvailable, err := parseMinAvailable(*minAvailable)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	destinationPorts, err := parsePorts(*ports)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	var peerSelector labels.Selector
+	if *peerLabels != "" {
+		if peerSelector, err = parseLabelSelector(*peerLabels); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(1)
+		}
+	}
+	nodeSelector, err := parseLabelSelector(*nodeLabels)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	weighting, err := targeting.ParseWeighting(*weightBy)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	processInterval, err := time.ParseDuration(*killInterval)
+	if err != nil {
+		fmt.Printf("❌ invalid kill interval %q: %v\n", *killInterval, err)
+		os.Exit(1)
+	}
+	clockOffset, err := time.ParseDuration(*timeOffset)
+	if err != nil {
+		fmt.Printf("❌ invalid time offset %q: %v\n", *timeOffset, err)
+		os.Exit(1)
+	}
+
+	// The root context is cancelled on SIGINT/SIGTERM
+	ctx := signalContext()
+
+	// Every random choice of the run is drawn from this source, so a run can be replayed
+	rng, runSeed := newRunRand(*seed)
 
 	// Get kubeconfig path - handle Windows and Unix paths
 	var kubeconfig string
@@ -97,7 +230,7 @@ func main() {
 	}
 
 	fmt.Println("🎭 Chaos Monkey Starting...")
-	fmt.Printf("📦 Operating in namespace: %s\n", *namespace)
+	fmt.Printf("🎲 Seed: %d (replay with -seed=%d)\n", runSeed, runSeed)
 
 	// Parse duration
 	chaosDuration, err := time.ParseDuration(*duration)
@@ -105,205 +238,317 @@ func main() {
 		panic(fmt.Sprintf("Invalid duration format: %v", err))
 	}
 
+	grace, err := time.ParseDuration(*shutdownGrace)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid shutdown grace period: %v", err))
+	}
+
+	// Parse network chaos settings
+	networkLatency, err := time.ParseDuration(*latency)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid latency format: %v", err))
+	}
+	networkJitter, err := time.ParseDuration(*jitter)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid jitter format: %v", err))
+	}
+
+	env := ChaosEnv{RestConfig: config, Clientset: clientset, RevertTimeout: grace}
+
+	chaosConfig := ChaosConfig{
+		Type:               ChaosType(*chaosType),
+		Namespace:          *namespace,
+		NamespaceSelection: namespaceSelection,
+		LabelSelector:      labelSelector,
+		FieldSelector:      *fieldFilter,
+		ReadyOnly:          *readyOnly,
+		Duration:           chaosDuration,
+		Intensity:          *intensity,
+		TargetCount:        *deleteCount,
+		TargetPercent:      *targetPercent,
+		TargetMin:          *targetMin,
+		TargetMax:          *targetMax,
+		DryRun:             *dryRun,
+		Container:          *containerName,
+		Strict:             *strict,
+		IgnorePDB:          *ignorePDB,
+		MinAvailable:       podMinAvailable,
+		Selection: targeting.Selection{
+			Strategy:    targeting.Strategy(*selection),
+			MaxPerOwner: *maxPerOwner,
+			OwnerKind:   *ownerKind,
+			Weighting:   weighting,
+		},
+		Network: NetworkChaosConfig{
+			Interface: *netInterface,
+			Latency:   networkLatency,
+			Jitter:    networkJitter,
+			Loss:      *loss,
+			Corrupt:   *corrupt,
+			Duplicate: *duplicate,
+			Reorder:   *reorder,
+			Rate:      *rate,
+
+			Destinations: splitList(*destinations),
+			Ports:        destinationPorts,
+			PeerSelector: peerSelector,
+		},
+		Node: NodeChaosConfig{
+			LabelSelector: nodeSelector,
+			Zones:         splitList(*zones),
+		},
+		DNS: DNSChaosConfig{
+			Mode:    *dnsMode,
+			Domains: splitList(*dnsDomains),
+			Answer:  *dnsAnswer,
+		},
+		Disk: DiskChaosConfig{
+			Path:        *fillPath,
+			FillPercent: *fillPercent,
+		},
+		Process: ProcessChaosConfig{
+			Match:        *processMatch,
+			Signal:       parseSignal(*killSignal),
+			AllowPID1:    *allowPID1,
+			Interval:     processInterval,
+			MaxProcesses: *maxProcesses,
+		},
+		Workload: WorkloadChaosConfig{
+			ScalePercent: *scalePercent,
+		},
+		Time: TimeChaosConfig{
+			Offset:      clockOffset,
+			HelperImage: *timeHelperImage,
+		},
+		Rand: rng,
+	}
+
+	fmt.Printf("📦 Operating in namespace: %s\n", chaosConfig.namespaceScope())
+
 	// Handle cleanup mode
 	if *cleanup {
-		err := CleanupTestPods(clientset, *namespace)
+		err := forEachTargetNamespace(ctx, clientset, chaosConfig, func(namespace string) error {
+			var testPodsErr error
+			if err := CleanupTestPods(ctx, clientset, namespace); err != nil {
+				testPodsErr = fmt.Errorf("failed to cleanup test pods: %v", err)
+			}
+			// Also cleanup chaos jobs, even when the test pods could not be removed
+			return errors.Join(testPodsErr, CleanupChaosJobs(ctx, config, clientset, namespace))
+		})
 		if err != nil {
-			panic(fmt.Sprintf("Failed to cleanup test pods: %v", err))
+			fmt.Printf("Warning: Cleanup incomplete: %v\n", err)
 		}
-		
-		// Also cleanup chaos jobs
-		err = CleanupChaosJobs(clientset, *namespace)
-		if err != nil {
-			fmt.Printf("Warning: Failed to cleanup chaos jobs: %v\n", err)
+		// Nodes are not namespaced, they are restored whatever the namespace selection
+		if err := CleanupNodeChaos(ctx, clientset, "", ""); err != nil {
+			fmt.Printf("Warning: Node cleanup incomplete: %v\n", err)
 		}
 		return
 	}
 
-	// Handle cron trigger mode
-	if *cronSchedule != "" {
-		fmt.Printf("⏰ Starting cron chaos trigger with schedule: %s\n", *cronSchedule)
-		
-		cronConfig := CronTriggerConfig{
-			Schedule:    *cronSchedule,
-			ChaosType:   ChaosType(*chaosType),
-			Probability: *probability,
-			MaxDuration: chaosDuration,
+	flagExperiment := Experiment{
+		Name:        "command line",
+		Chaos:       chaosConfig,
+		Schedule:    *cronSchedule,
+		Probability: *probability,
+		Abort: AbortConditions{
+			MaxRestarts:     *abortRestarts,
+			MinReadyPercent: *abortMinReady,
+			OnPodFailure:    *abortPodFailure,
+			Interval:        defaultAbortInterval,
+		},
+	}
+	// Flags given explicitly on the command line win over experiment and scenario files
+	override := func(experiment *Experiment) {
+		flag.Visit(func(f *flag.Flag) {
+			if override, ok := flagOverrides[f.Name]; ok {
+				override(experiment, flagExperiment)
+			}
+		})
+	}
+
+	var scenario *Scenario
+	experiments := []*Experiment{&flagExperiment}
+	switch {
+	case *scenarioFile != "" && *experimentFile != "":
+		fmt.Println("❌ -f and -scenario cannot be used together")
+		os.Exit(1)
+	case *scenarioFile != "":
+		scenario, err = LoadScenarioFile(*scenarioFile, flagExperiment, override)
+		if err != nil {
+			fmt.Printf("❌ Invalid scenario file:\n%v\n", err)
+			os.Exit(1)
+		}
+	case *experimentFile != "":
+		experiments, err = LoadExperimentFile(*experimentFile, flagExperiment, override)
+		if err != nil {
+			fmt.Printf("❌ Invalid experiment file:\n%v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("📄 Loaded %d experiments from %s\n", len(experiments), *experimentFile)
+	default:
+		// Ad-hoc cron triggers pick their own intensity and target count
+		flagExperiment.RandomizeTriggers = true
+		problems := validateExperiment(&flagExperiment, ExperimentSpec{})
+		for _, problem := range problems {
+			fmt.Printf("❌ Invalid %s configuration: %s\n", chaosConfig.Type, problem.msg)
+		}
+		if len(problems) > 0 {
+			os.Exit(1)
 		}
-		
-		StartCronTrigger(clientset, cronConfig)
-		
-		// Keep the program running for cron triggers
-		fmt.Println("🔄 Cron trigger started. Press Ctrl+C to stop...")
-		select {} // Wait indefinitely
 	}
 
 	// Create test pods if requested
 	if *createPods {
+		podLabels, unsupported := selectorLabels(labelSelector)
+		if len(unsupported) > 0 {
+			fmt.Printf("⚠️  Label requirements %v are not applied to test pods\n", unsupported)
+		}
 		config := TestPodConfig{
 			Count:     *podCount,
 			Namespace: *namespace,
-			Labels:    parseLabels(*labelFilter),
+			Labels:    podLabels,
+			Rand:      rng,
 		}
-		err := CreateTestPods(clientset, config)
+		err := CreateTestPods(ctx, clientset, config)
 		if err != nil {
 			panic(fmt.Sprintf("Failed to create test pods: %v", err))
 		}
 	}
 
-	// List pods with optional label filter
-	listOptions := metav1.ListOptions{}
-	if *labelFilter != "" {
-		listOptions.LabelSelector = *labelFilter
-	}
-
-	pods, err := clientset.CoreV1().Pods(*namespace).List(context.TODO(), listOptions)
-	if err != nil {
-		panic(fmt.Sprintf("Failed to list pods: %v", err))
-	}
-
-	if len(pods.Items) == 0 {
-		fmt.Printf("❌ No pods found in namespace: %s", *namespace)
-		if *labelFilter != "" {
-			fmt.Printf(" with labels: %s", *labelFilter)
+	if scenario != nil {
+		results := RunScenario(ctx, env, scenario)
+		passed := PrintScenarioReport(scenario, results)
+		if ctx.Err() != nil {
+			shutdown(env, grace)
 		}
-		fmt.Println()
-		
-		if !*createPods {
-			fmt.Println("💡 Tip: Use -create flag to create test pods automatically")
+		if !passed {
+			os.Exit(1)
 		}
 		return
 	}
 
-	// Filter out pods that are being terminated or are in error state
-	var availablePods []v1.Pod
-	for _, pod := range pods.Items {
-		if pod.Status.Phase != v1.PodSucceeded && 
-		   pod.Status.Phase != v1.PodFailed && 
-		   pod.DeletionTimestamp == nil {
-			availablePods = append(availablePods, pod)
+	scheduled := false
+	failed := false
+	for _, experiment := range experiments {
+		if ctx.Err() != nil {
+			break
 		}
-	}
-
-	if len(availablePods) == 0 {
-		fmt.Printf("❌ No available pods found")
-		if *labelFilter != "" {
-			fmt.Printf(" with labels: %s", *labelFilter)
+		started, err := runExperiment(ctx, env, experiment)
+		if err != nil {
+			fmt.Printf("❌ %s chaos failed: %v\n", experiment.Chaos.Type, err)
+			if !*createPods {
+				fmt.Println("💡 Tip: Use -create flag to create test pods automatically")
+			}
+			failed = true
 		}
-		fmt.Println()
-		return
+		scheduled = scheduled || started
 	}
 
-	// Handle different chaos types
-	chaosConfig := ChaosConfig{
-		Type:        ChaosType(*chaosType),
-		Namespace:   *namespace,
-		Labels:      parseLabels(*labelFilter),
-		Duration:    chaosDuration,
-		Intensity:   *intensity,
-		TargetCount: *deleteCount,
-	}
-
-	switch ChaosType(*chaosType) {
-	case ChaosTypePodDelete:
-		// Original pod deletion logic
-		applyPodDeleteChaos(clientset, availablePods, chaosConfig, *dryRun)
-	case ChaosTypeCPUStress:
-		ApplyCPUStress(clientset, chaosConfig)
-	case ChaosTypeMemoryStress:
-		ApplyMemoryStress(clientset, chaosConfig)
-	case ChaosTypeInPodCPUStress:
-		ApplyInPodCPUStress(config, clientset, chaosConfig)
-	case ChaosTypeInPodMemoryStress:
-		ApplyInPodMemoryStress(config, clientset, chaosConfig)
-	case ChaosTypeInPodMixedStress:
-		ApplyInPodMixedStress(config, clientset, chaosConfig)
-	case ChaosTypeKillProcess:
-		ApplyKillProcessChaos(config, clientset, chaosConfig)
-	case ChaosTypeCorruptMemory:
-		ApplyCorruptMemoryChaos(config, clientset, chaosConfig)
-	default:
-		fmt.Printf("⚠️  Unknown chaos type: %s, falling back to pod deletion\n", *chaosType)
-		applyPodDeleteChaos(clientset, availablePods, chaosConfig, *dryRun)
-	}
-}
-
-// selectRandomPods selects random pods without duplicates
-func selectRandomPods(pods []v1.Pod, count int) []v1.Pod {
-	if count >= len(pods) {
-		return pods
-	}
-	
-	// Create a copy of the slice to avoid modifying the original
-	podCopy := make([]v1.Pod, len(pods))
-	copy(podCopy, pods)
-	
-	// Fisher-Yates shuffle to get random pods
-	selected := make([]v1.Pod, 0, count)
-	for i := 0; i < count; i++ {
-		// Pick a random index from remaining pods
-		randomIndex := rand.Intn(len(podCopy))
-		selected = append(selected, podCopy[randomIndex])
-		
-		// Remove the selected pod from the copy
-		podCopy = append(podCopy[:randomIndex], podCopy[randomIndex+1:]...)
-	}
-	
-	return selected
-}
-
-// applyPodDeleteChaos applies pod deletion chaos
-func applyPodDeleteChaos(clientset *kubernetes.Clientset, availablePods []v1.Pod, config ChaosConfig, dryRun bool) {
-	// Determine how many pods to delete
-	podsToDelete := config.TargetCount
-	if podsToDelete > len(availablePods) {
-		podsToDelete = len(availablePods)
-		fmt.Printf("⚠️  Requested to delete %d pods but only %d are available\n", config.TargetCount, len(availablePods))
+	if scheduled {
+		// Keep the program running for cron triggers
+		fmt.Println("🔄 Cron trigger started. Press Ctrl+C to stop...")
+		<-ctx.Done()
 	}
-
-	// Select random pods to delete
-	selectedPods := selectRandomPods(availablePods, podsToDelete)
-
-	if dryRun {
-		fmt.Println("🔍 DRY RUN MODE - No pods will be deleted")
-		fmt.Printf("📋 Would delete %d pods:\n", len(selectedPods))
-		for i, pod := range selectedPods {
-			fmt.Printf("  %d. %s (Status: %s)\n", i+1, pod.Name, pod.Status.Phase)
-		}
-		return
+	if ctx.Err() != nil {
+		shutdown(env, grace)
 	}
-
-	// Delete the selected pods
-	deletedPods := []string{}
-	for i, pod := range selectedPods {
-		fmt.Printf("💀 Deleting pod %d/%d: %s\n", i+1, len(selectedPods), pod.Name)
-		err := clientset.CoreV1().Pods(config.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
-		if err != nil {
-			fmt.Printf("❌ Failed to delete pod %s: %v\n", pod.Name, err)
-		} else {
-			deletedPods = append(deletedPods, pod.Name)
-		}
+	if failed {
+		os.Exit(1)
 	}
-
-	fmt.Printf("✅ Successfully deleted %d/%d pods!\n", len(deletedPods), len(selectedPods))
-	fmt.Printf("📊 Summary: Deleted pods %v from namespace '%s'\n", deletedPods, config.Namespace)
 }
 
-// parseLabels converts a comma-separated label string to a map
-func parseLabels(labelString string) map[string]string {
-	labels := make(map[string]string)
-	if labelString == "" {
-		return labels
-	}
-	
-	pairs := strings.Split(labelString, ",")
-	for _, pair := range pairs {
-		parts := strings.Split(strings.TrimSpace(pair), "=")
-		if len(parts) == 2 {
-			labels[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
-		}
-	}
-	return labels
+// flagOverrides copies the value of an explicitly set flag from the flag
+// experiment onto an experiment loaded from a file
+var flagOverrides = map[string]func(dst *Experiment, flags Experiment){
+	"chaos-type": func(dst *Experiment, flags Experiment) { dst.Chaos.Type = flags.Chaos.Type },
+	"namespace":  func(dst *Experiment, flags Experiment) { dst.Chaos.Namespace = flags.Chaos.Namespace },
+	"namespaces": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.NamespaceSelection.Names = flags.Chaos.NamespaceSelection.Names
+	},
+	"all-namespaces": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.NamespaceSelection.Names = flags.Chaos.NamespaceSelection.Names
+	},
+	"namespace-selector": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.NamespaceSelection.Selector = flags.Chaos.NamespaceSelection.Selector
+	},
+	"deny-namespaces": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.NamespaceSelection.Deny = append(dst.Chaos.NamespaceSelection.Deny, flags.Chaos.NamespaceSelection.Deny...)
+	},
+	"labels":         func(dst *Experiment, flags Experiment) { dst.Chaos.LabelSelector = flags.Chaos.LabelSelector },
+	"field-selector": func(dst *Experiment, flags Experiment) { dst.Chaos.FieldSelector = flags.Chaos.FieldSelector },
+	"ready-only":     func(dst *Experiment, flags Experiment) { dst.Chaos.ReadyOnly = flags.Chaos.ReadyOnly },
+	"delete-count":   func(dst *Experiment, flags Experiment) { dst.Chaos.TargetCount = flags.Chaos.TargetCount },
+	"target-percent": func(dst *Experiment, flags Experiment) { dst.Chaos.TargetPercent = flags.Chaos.TargetPercent },
+	"target-min":     func(dst *Experiment, flags Experiment) { dst.Chaos.TargetMin = flags.Chaos.TargetMin },
+	"target-max":     func(dst *Experiment, flags Experiment) { dst.Chaos.TargetMax = flags.Chaos.TargetMax },
+	"weight-by": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.Selection.Weighting = flags.Chaos.Selection.Weighting
+	},
+	"selection": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.Selection.Strategy = flags.Chaos.Selection.Strategy
+	},
+	"max-per-owner": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.Selection.MaxPerOwner = flags.Chaos.Selection.MaxPerOwner
+	},
+	"owner-kind": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.Selection.OwnerKind = flags.Chaos.Selection.OwnerKind
+	},
+	"dry-run":       func(dst *Experiment, flags Experiment) { dst.Chaos.DryRun = flags.Chaos.DryRun },
+	"strict":        func(dst *Experiment, flags Experiment) { dst.Chaos.Strict = flags.Chaos.Strict },
+	"ignore-pdb":    func(dst *Experiment, flags Experiment) { dst.Chaos.IgnorePDB = flags.Chaos.IgnorePDB },
+	"min-available": func(dst *Experiment, flags Experiment) { dst.Chaos.MinAvailable = flags.Chaos.MinAvailable },
+	"intensity":     func(dst *Experiment, flags Experiment) { dst.Chaos.Intensity = flags.Chaos.Intensity },
+	"duration":      func(dst *Experiment, flags Experiment) { dst.Chaos.Duration = flags.Chaos.Duration },
+	"latency":       func(dst *Experiment, flags Experiment) { dst.Chaos.Network.Latency = flags.Chaos.Network.Latency },
+	"jitter":        func(dst *Experiment, flags Experiment) { dst.Chaos.Network.Jitter = flags.Chaos.Network.Jitter },
+	"loss":          func(dst *Experiment, flags Experiment) { dst.Chaos.Network.Loss = flags.Chaos.Network.Loss },
+	"corrupt":       func(dst *Experiment, flags Experiment) { dst.Chaos.Network.Corrupt = flags.Chaos.Network.Corrupt },
+	"duplicate":     func(dst *Experiment, flags Experiment) { dst.Chaos.Network.Duplicate = flags.Chaos.Network.Duplicate },
+	"reorder":       func(dst *Experiment, flags Experiment) { dst.Chaos.Network.Reorder = flags.Chaos.Network.Reorder },
+	"rate":          func(dst *Experiment, flags Experiment) { dst.Chaos.Network.Rate = flags.Chaos.Network.Rate },
+	"destinations": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.Network.Destinations = flags.Chaos.Network.Destinations
+	},
+	"peer-labels": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.Network.PeerSelector = flags.Chaos.Network.PeerSelector
+	},
+	"ports":        func(dst *Experiment, flags Experiment) { dst.Chaos.Network.Ports = flags.Chaos.Network.Ports },
+	"interface":    func(dst *Experiment, flags Experiment) { dst.Chaos.Network.Interface = flags.Chaos.Network.Interface },
+	"dns-mode":     func(dst *Experiment, flags Experiment) { dst.Chaos.DNS.Mode = flags.Chaos.DNS.Mode },
+	"dns-domains":  func(dst *Experiment, flags Experiment) { dst.Chaos.DNS.Domains = flags.Chaos.DNS.Domains },
+	"dns-answer":   func(dst *Experiment, flags Experiment) { dst.Chaos.DNS.Answer = flags.Chaos.DNS.Answer },
+	"fill-path":    func(dst *Experiment, flags Experiment) { dst.Chaos.Disk.Path = flags.Chaos.Disk.Path },
+	"fill-percent": func(dst *Experiment, flags Experiment) { dst.Chaos.Disk.FillPercent = flags.Chaos.Disk.FillPercent },
+	"time-offset":  func(dst *Experiment, flags Experiment) { dst.Chaos.Time.Offset = flags.Chaos.Time.Offset },
+	"time-helper-image": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.Time.HelperImage = flags.Chaos.Time.HelperImage
+	},
+	"process":    func(dst *Experiment, flags Experiment) { dst.Chaos.Process.Match = flags.Chaos.Process.Match },
+	"signal":     func(dst *Experiment, flags Experiment) { dst.Chaos.Process.Signal = flags.Chaos.Process.Signal },
+	"allow-pid1": func(dst *Experiment, flags Experiment) { dst.Chaos.Process.AllowPID1 = flags.Chaos.Process.AllowPID1 },
+	"kill-interval": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.Process.Interval = flags.Chaos.Process.Interval
+	},
+	"max-processes": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.Process.MaxProcesses = flags.Chaos.Process.MaxProcesses
+	},
+	"container": func(dst *Experiment, flags Experiment) { dst.Chaos.Container = flags.Chaos.Container },
+	"scale-percent": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.Workload.ScalePercent = flags.Chaos.Workload.ScalePercent
+	},
+	"node-labels": func(dst *Experiment, flags Experiment) {
+		dst.Chaos.Node.LabelSelector = flags.Chaos.Node.LabelSelector
+	},
+	"zones":       func(dst *Experiment, flags Experiment) { dst.Chaos.Node.Zones = flags.Chaos.Node.Zones },
+	"cron":        func(dst *Experiment, flags Experiment) { dst.Schedule = flags.Schedule },
+	"probability": func(dst *Experiment, flags Experiment) { dst.Probability = flags.Probability },
+	"abort-max-restarts": func(dst *Experiment, flags Experiment) {
+		dst.Abort.MaxRestarts = flags.Abort.MaxRestarts
+	},
+	"abort-min-ready": func(dst *Experiment, flags Experiment) {
+		dst.Abort.MinReadyPercent = flags.Abort.MinReadyPercent
+	},
+	"abort-on-pod-failure": func(dst *Experiment, flags Experiment) {
+		dst.Abort.OnPodFailure = flags.Abort.OnPodFailure
+	},
 }
-
- 
\ No newline at end of file
@@ -0,0 +1,390 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// networkChaosAnnotation marks pods that currently have a netem qdisc installed
+// by kubechaos, so that -cleanup can find and remove it later
+const networkChaosAnnotation = "kubechaos.io/network-chaos"
+
+func init() {
+	for chaosType, description := range map[ChaosType]string{
+		ChaosTypeNetworkLatency:   "Add latency and jitter to pod egress traffic (tc netem)",
+		ChaosTypeNetworkLoss:      "Drop a percentage of pod egress packets (tc netem)",
+		ChaosTypeNetworkCorrupt:   "Corrupt a percentage of pod egress packets (tc netem)",
+		ChaosTypeNetworkDuplicate: "Duplicate a percentage of pod egress packets (tc netem)",
+		ChaosTypeNetworkReorder:   "Reorder a percentage of pod egress packets (tc netem)",
+		ChaosTypeNetworkBandwidth: "Limit the rate of pod egress traffic (tc netem)",
+	} {
+		RegisterInjector(&funcInjector{
+			name:     chaosType,
+			validate: validateNetem,
+			inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+				return ApplyNetemChaos(ctx, env.RestConfig, env.Clientset, config)
+			},
+			revert: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+				return forEachTargetNamespace(ctx, env.Clientset, config, func(namespace string) error {
+					return CleanupNetworkChaos(ctx, env.RestConfig, env.Clientset, namespace, config.RunID)
+				})
+			},
+		}, description)
+	}
+}
+
+// netemRatePattern matches the rates tc accepts, e.g. "1mbit" or "500kbps"
+var netemRatePattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?([kmgt]?bit|[kmgt]?bps)$`)
+
+// networkChaosMarker is stored in the networkChaosAnnotation of a target pod
+type networkChaosMarker struct {
+	Container string `json:"container"`
+	Interface string `json:"interface"`
+}
+
+// tcInstallCommand makes sure the tc binary is available inside the container
+const tcInstallCommand = "command -v tc >/dev/null 2>&1 || " +
+	"(apk add --no-cache iproute2 || (apt-get update && apt-get install -y iproute2) || yum install -y iproute) >/dev/null 2>&1"
+
+// netemPidFile returns the path of the file holding the PID of the in-pod revert watchdog
+func netemPidFile(iface string) string {
+	return fmt.Sprintf("/tmp/.kubechaos-netem-%s.pid", iface)
+}
+
+// tcDuration formats a duration the way tc expects it
+func tcDuration(d time.Duration) string {
+	return fmt.Sprintf("%dus", d.Microseconds())
+}
+
+// netemArgs returns the netem parameters of the chaos type, e.g. "loss 10%"
+func netemArgs(chaosType ChaosType, network NetworkChaosConfig) string {
+	switch chaosType {
+	case ChaosTypeNetworkLoss:
+		return fmt.Sprintf("loss %g%%", network.Loss)
+	case ChaosTypeNetworkCorrupt:
+		return fmt.Sprintf("corrupt %g%%", network.Corrupt)
+	case ChaosTypeNetworkDuplicate:
+		return fmt.Sprintf("duplicate %g%%", network.Duplicate)
+	case ChaosTypeNetworkReorder:
+		// netem only reorders delayed packets: the others are sent at once
+		return fmt.Sprintf("delay %s reorder %g%% 50%%", tcDuration(network.Latency), network.Reorder)
+	case ChaosTypeNetworkBandwidth:
+		return "rate " + network.Rate
+	}
+	args := "delay " + tcDuration(network.Latency)
+	if network.Jitter > 0 {
+		args += fmt.Sprintf(" %s distribution normal", tcDuration(network.Jitter))
+	}
+	return args
+}
+
+// generateNetemCommand creates the command that installs the netem qdisc of the chaos
+// type on the interface. When destinations or ports are configured, the netem qdisc
+// hangs off an extra band of a prio qdisc and only the matching traffic is filtered
+// into it. A watchdog is started in the background so the qdiscs are removed inside
+// the pod even if kubechaos dies before it gets a chance to revert them.
+func generateNetemCommand(chaosType ChaosType, network NetworkChaosConfig, duration time.Duration) string {
+	cmd := tcInstallCommand + " && "
+	if len(network.Destinations) == 0 && len(network.Ports) == 0 {
+		cmd += fmt.Sprintf("tc qdisc replace dev %s root netem %s", network.Interface, netemArgs(chaosType, network))
+	} else {
+		// The default priomap only uses bands 1-3, so band 4 gets the filtered traffic alone
+		cmd += fmt.Sprintf("tc qdisc replace dev %s root handle 1: prio bands 4", network.Interface)
+		cmd += fmt.Sprintf(" && tc qdisc add dev %s parent 1:4 handle 40: netem %s", network.Interface, netemArgs(chaosType, network))
+		for _, filter := range netemFilterCommands(network.Interface, network.Destinations, network.Ports) {
+			cmd += " && " + filter
+		}
+	}
+	cmd += fmt.Sprintf(" && (nohup sh -c 'sleep %d; tc qdisc del dev %s root' >/dev/null 2>&1 & echo $! > %s)",
+		int((duration + 30*time.Second).Seconds()), network.Interface, netemPidFile(network.Interface))
+	return cmd
+}
+
+// netemFilterCommands returns the tc filters that send the traffic to the
+// destination CIDRs and/or ports through the netem band
+func netemFilterCommands(iface string, destinations []string, ports []int) []string {
+	var cmds []string
+	add := func(protocol, match string) {
+		// Filters of different protocols cannot share a priority
+		prio := 1
+		if protocol == "ipv6" {
+			prio = 2
+		}
+		cmds = append(cmds, fmt.Sprintf("tc filter add dev %s parent 1:0 protocol %s prio %d u32 %s flowid 1:4", iface, protocol, prio, match))
+	}
+	portMatch := func(family string, port int) string {
+		return fmt.Sprintf("match %s dport %d 0xffff", family, port)
+	}
+
+	if len(destinations) == 0 {
+		for _, port := range ports {
+			add("ip", portMatch("ip", port))
+			add("ipv6", portMatch("ip6", port))
+		}
+		return cmds
+	}
+	for _, cidr := range destinations {
+		protocol, family := "ip", "ip"
+		if strings.Contains(cidr, ":") {
+			protocol, family = "ipv6", "ip6"
+		}
+		dst := fmt.Sprintf("match %s dst %s", family, cidr)
+		if len(ports) == 0 {
+			add(protocol, dst)
+			continue
+		}
+		for _, port := range ports {
+			add(protocol, dst+" "+portMatch(family, port))
+		}
+	}
+	return cmds
+}
+
+// generateNetemRevertCommand creates the command that removes the netem qdisc and its watchdog
+func generateNetemRevertCommand(iface string) string {
+	pidFile := netemPidFile(iface)
+	return fmt.Sprintf("(kill $(cat %s) 2>/dev/null; rm -f %s); tc qdisc del dev %s root 2>/dev/null || true",
+		pidFile, pidFile, iface)
+}
+
+// validateNetem checks the settings of the netem chaos types
+func validateNetem(config ChaosConfig) error {
+	network := config.Network
+	percent := func(name string, value float64) error {
+		if value <= 0 || value > 100 {
+			return fmt.Errorf("network %s must be between 0 and 100%%, got %g", name, value)
+		}
+		return nil
+	}
+
+	var err error
+	switch config.Type {
+	case ChaosTypeNetworkLoss:
+		err = percent("loss", network.Loss)
+	case ChaosTypeNetworkCorrupt:
+		err = percent("corruption", network.Corrupt)
+	case ChaosTypeNetworkDuplicate:
+		err = percent("duplication", network.Duplicate)
+	case ChaosTypeNetworkReorder:
+		err = percent("reordering", network.Reorder)
+		if err == nil && network.Latency <= 0 {
+			err = fmt.Errorf("network reordering needs a latency greater than zero")
+		}
+	case ChaosTypeNetworkBandwidth:
+		if !netemRatePattern.MatchString(network.Rate) {
+			err = fmt.Errorf("invalid network rate %q, expected e.g. 1mbit or 500kbps", network.Rate)
+		}
+	default:
+		if network.Latency <= 0 {
+			err = fmt.Errorf("network latency must be greater than zero")
+		} else if network.Jitter < 0 {
+			err = fmt.Errorf("network jitter must not be negative")
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if network.Interface == "" {
+		return fmt.Errorf("network interface must not be empty")
+	}
+	for _, cidr := range network.Destinations {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid network destination %q: %v", cidr, err)
+		}
+	}
+	for _, port := range network.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("invalid network port %d", port)
+		}
+	}
+	return nil
+}
+
+// describeNetem describes the fault of the chaos type for log output
+func describeNetem(chaosType ChaosType, network NetworkChaosConfig) string {
+	description := netemArgs(chaosType, network)
+	if len(network.Destinations) > 0 {
+		description += " to " + strings.Join(network.Destinations, ",")
+	}
+	if len(network.Ports) > 0 {
+		var ports []string
+		for _, port := range network.Ports {
+			ports = append(ports, strconv.Itoa(port))
+		}
+		description += " on ports " + strings.Join(ports, ",")
+	}
+	return description
+}
+
+// ApplyNetemChaos installs the netem fault of the chaos type on the egress traffic
+// of selected pods for the configured duration, then removes it again
+func ApplyNetemChaos(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
+	fmt.Printf("🐢 Applying %s chaos to namespace: %s\n", chaosConfig.Type, chaosConfig.namespaceScope())
+
+	selectedPods, err := selectTargets(ctx, clientset, chaosConfig)
+	if err != nil {
+		return err
+	}
+
+	cmd := generateNetemCommand(chaosConfig.Type, chaosConfig.Network, chaosConfig.Duration)
+	if chaosConfig.DryRun {
+		fmt.Println("🔍 DRY RUN MODE - No traffic will be faulted")
+		fmt.Printf("📋 Would apply %s (%s) to %d pods:\n", chaosConfig.Type, describeNetem(chaosConfig.Type, chaosConfig.Network), len(selectedPods))
+		for i, pod := range selectedPods {
+			fmt.Printf("  %d. %s/%s\n", i+1, pod.Namespace, pod.Name)
+		}
+		fmt.Printf("📋 Command: %s\n", cmd)
+		return nil
+	}
+
+	var faultedPods []v1.Pod
+	var unreverted []string // Pods whose qdisc could not be removed after a failed injection
+	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			break
+		}
+		if len(pod.Spec.Containers) == 0 {
+			fmt.Printf("⚠️  Pod %s has no containers, skipping\n", pod.Name)
+			continue
+		}
+		if _, busy := pod.Annotations[networkChaosAnnotation]; busy {
+			fmt.Printf("⚠️  Pod %s already has network chaos, skipping\n", pod.Name)
+			continue
+		}
+		marker := networkChaosMarker{
+			Container: pod.Spec.Containers[0].Name,
+			Interface: chaosConfig.Network.Interface,
+		}
+
+		fmt.Printf("🐢 Faulting traffic for pod %d/%d: %s (container: %s, %s)\n", i+1, len(selectedPods),
+			pod.Name, marker.Container, describeNetem(chaosConfig.Type, chaosConfig.Network))
+		fmt.Printf("📋 Command: %s\n", cmd)
+
+		// Record the fault before injecting it so -cleanup can always find it
+		if err := setNetworkChaosMarker(ctx, clientset, pod.Namespace, pod.Name, &marker, chaosConfig.RunID); err != nil {
+			fmt.Printf("❌ Failed to annotate pod %s: %v\n", pod.Name, err)
+			continue
+		}
+
+		err := execInPod(ctx, config, clientset, pod.Namespace, pod.Name, marker.Container, cmd)
+		if err != nil {
+			fmt.Printf("❌ Failed to apply %s in pod %s: %v\n", chaosConfig.Type, pod.Name, err)
+			if revertNetworkChaos(ctx, config, clientset, pod.Namespace, pod.Name, marker) != nil {
+				unreverted = append(unreverted, pod.Namespace+"/"+pod.Name)
+			}
+			continue
+		}
+		fmt.Printf("✅ Successfully applied %s to pod: %s\n", chaosConfig.Type, pod.Name)
+		faultedPods = append(faultedPods, pod)
+	}
+
+	if len(faultedPods) == 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if len(unreverted) > 0 {
+			return fmt.Errorf("failed to apply %s to any pod, and failed to remove it from pods %v", chaosConfig.Type, unreverted)
+		}
+		return fmt.Errorf("failed to apply %s to any pod", chaosConfig.Type)
+	}
+
+	fmt.Printf("⏳ Holding %s for %s...\n", chaosConfig.Type, chaosConfig.Duration)
+	if holdChaos(ctx, chaosConfig.Duration) != nil {
+		// The markers stay on the pods so the rollback can remove the qdiscs
+		fmt.Printf("🛑 %s interrupted\n", chaosConfig.Type)
+		return ctx.Err()
+	}
+
+	for _, pod := range faultedPods {
+		err := revertNetworkChaos(ctx, config, clientset, pod.Namespace, pod.Name, networkChaosMarker{
+			Container: pod.Spec.Containers[0].Name,
+			Interface: chaosConfig.Network.Interface,
+		})
+		if err != nil {
+			unreverted = append(unreverted, pod.Namespace+"/"+pod.Name)
+		}
+	}
+	if len(unreverted) > 0 {
+		return fmt.Errorf("failed to remove %s from pods %v", chaosConfig.Type, unreverted)
+	}
+	return nil
+}
+
+// revertNetworkChaos removes the netem qdisc from the pod and clears its marker annotation
+func revertNetworkChaos(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, podName string, marker networkChaosMarker) error {
+	fmt.Printf("🔧 Removing network chaos from pod: %s (interface: %s)\n", podName, marker.Interface)
+
+	err := execInPod(ctx, config, clientset, namespace, podName, marker.Container, generateNetemRevertCommand(marker.Interface))
+	if err != nil {
+		fmt.Printf("❌ Failed to remove network chaos from pod %s: %v\n", podName, err)
+		return err
+	}
+
+	if err := setNetworkChaosMarker(ctx, clientset, namespace, podName, nil, ""); err != nil {
+		fmt.Printf("⚠️  Failed to remove network chaos annotation from pod %s: %v\n", podName, err)
+		return err
+	}
+	fmt.Printf("✅ Network chaos removed from pod: %s\n", podName)
+	return nil
+}
+
+// setNetworkChaosMarker stores the marker and run ID on the pod, or removes them when marker is nil
+func setNetworkChaosMarker(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName string, marker *networkChaosMarker, runID string) error {
+	if marker == nil {
+		return patchPodAnnotation(ctx, clientset, namespace, podName, networkChaosAnnotation, nil)
+	}
+	data, err := json.Marshal(marker)
+	if err != nil {
+		return err
+	}
+	return markPod(ctx, clientset, namespace, podName, networkChaosAnnotation, string(data), runID)
+}
+
+// CleanupNetworkChaos removes the network chaos the run left behind in the
+// namespace, or that of any run when runID is empty
+func CleanupNetworkChaos(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, runID string) error {
+	fmt.Printf("🧹 Cleaning up network chaos in namespace: %s\n", namespace)
+
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	cleaned := 0
+	var failed []string
+	for _, pod := range pods.Items {
+		value, ok := pod.Annotations[networkChaosAnnotation]
+		if !ok || !ownedByRun(pod.Annotations, networkChaosAnnotation, runID) {
+			continue
+		}
+
+		var marker networkChaosMarker
+		if err := json.Unmarshal([]byte(value), &marker); err != nil {
+			fmt.Printf("⚠️  Ignoring malformed network chaos annotation on pod %s: %v\n", pod.Name, err)
+			continue
+		}
+		if err := revertNetworkChaos(ctx, config, clientset, namespace, pod.Name, marker); err != nil {
+			failed = append(failed, pod.Name)
+			continue
+		}
+		cleaned++
+	}
+
+	fmt.Printf("✅ Cleaned up network chaos in %d pods\n", cleaned)
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to remove network chaos from pods %v", failed)
+	}
+	return nil
+}
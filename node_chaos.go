@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/iamkrati22/kubechaos/targeting"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// nodeChaosAnnotation marks nodes that kubechaos cordoned or tainted, and holds
+// their original state so it can be restored by the revert or by -cleanup
+const nodeChaosAnnotation = "kubechaos.io/node-chaos"
+
+// nodeChaosTaintKey is the key of the NoExecute taint added by node-taint chaos
+const nodeChaosTaintKey = "kubechaos.io/node-chaos"
+
+func init() {
+	for chaosType, description := range map[ChaosType]string{
+		ChaosTypeNodeCordon: "Cordon random nodes, restoring their schedulability afterwards",
+		ChaosTypeNodeDrain:  "Cordon random nodes and evict their pods (honours PodDisruptionBudgets)",
+		ChaosTypeNodeTaint:  "Add a NoExecute taint to random nodes, evicting pods that do not tolerate it",
+	} {
+		RegisterInjector(&funcInjector{
+			name:   chaosType,
+			inject: injectNodeChaos,
+			revert: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+				return CleanupNodeChaos(ctx, env.Clientset, config.Type, config.RunID)
+			},
+		}, description)
+	}
+}
+
+// nodeChaosMarker is stored in the nodeChaosAnnotation of a target node
+type nodeChaosMarker struct {
+	Type          ChaosType `json:"type"`
+	Unschedulable bool      `json:"unschedulable"` // Schedulability before the fault
+}
+
+// nodeCriteria builds the node selection criteria for a chaos configuration
+func (c ChaosConfig) nodeCriteria() targeting.NodeCriteria {
+	return targeting.NodeCriteria{
+		LabelSelector:      c.Node.LabelSelector,
+		Zones:              c.Node.Zones,
+		ExcludeAnnotations: []string{nodeChaosAnnotation},
+	}
+}
+
+// selectNodes finds the eligible nodes and picks TargetCount of them at random
+func selectNodes(ctx context.Context, clientset *kubernetes.Clientset, config ChaosConfig) ([]v1.Node, error) {
+	criteria := config.nodeCriteria()
+	candidates, err := targeting.FindNodes(ctx, clientset, criteria)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates.Nodes) == 0 {
+		if candidates.Listed == 0 {
+			return nil, fmt.Errorf("no nodes match %s", criteria)
+		}
+		return nil, fmt.Errorf("no available nodes match %s (%s)", criteria, describeExclusions(candidates.Excluded))
+	}
+
+	count := targetCount(config, len(candidates.Nodes))
+	var selected []v1.Node
+	for _, i := range config.random().Perm(len(candidates.Nodes))[:count] {
+		selected = append(selected, candidates.Nodes[i])
+	}
+	return selected, nil
+}
+
+// injectNodeChaos cordons, drains or taints random nodes for the configured
+// duration, then restores their original state
+func injectNodeChaos(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+	clientset := env.Clientset
+	fmt.Printf("🖥️  Applying %s chaos to %s\n", config.Type, config.nodeCriteria())
+
+	nodes, err := selectNodes(ctx, clientset, config)
+	if err != nil {
+		return err
+	}
+
+	if config.DryRun {
+		fmt.Println("🔍 DRY RUN MODE - No nodes will be changed")
+		fmt.Printf("📋 Would apply %s to %d nodes:\n", config.Type, len(nodes))
+		for i, node := range nodes {
+			fmt.Printf("  %d. %s (zone: %s)\n", i+1, node.Name, nodeZone(node))
+			if config.Type == ChaosTypeNodeDrain {
+				pods, err := drainablePods(ctx, clientset, node.Name, config)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("     %d pods would be evicted\n", len(pods))
+			}
+		}
+		return nil
+	}
+
+	var faulted []string
+	for i, node := range nodes {
+		if ctx.Err() != nil {
+			break
+		}
+		fmt.Printf("🖥️  %s node %d/%d: %s (zone: %s)\n", config.Type, i+1, len(nodes), node.Name, nodeZone(node))
+		if err := applyNodeChaos(ctx, clientset, node.Name, config.Type, config.RunID); err != nil {
+			fmt.Printf("❌ Failed to apply %s to node %s: %v\n", config.Type, node.Name, err)
+			continue
+		}
+		faulted = append(faulted, node.Name)
+		if config.Type == ChaosTypeNodeDrain {
+			drainNode(ctx, clientset, node.Name, config)
+		}
+	}
+
+	if len(faulted) == 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to apply %s to any node", config.Type)
+	}
+
+	fmt.Printf("⏳ Holding %s on %v for %s...\n", config.Type, faulted, config.Duration)
+	if holdChaos(ctx, config.Duration) != nil {
+		// The markers stay on the nodes so the rollback can restore them
+		fmt.Printf("🛑 %s interrupted\n", config.Type)
+		return ctx.Err()
+	}
+
+	var failed []string
+	for _, name := range faulted {
+		if err := restoreNode(ctx, clientset, name); err != nil {
+			failed = append(failed, name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to restore nodes %v", failed)
+	}
+	return nil
+}
+
+// nodeZone returns the zone of the node, or "-" when it has none
+func nodeZone(node v1.Node) string {
+	if zone := node.Labels[v1.LabelTopologyZone]; zone != "" {
+		return zone
+	}
+	return "-"
+}
+
+// applyNodeChaos records the node's original state in its marker annotation and
+// cordons or taints it, in a single update
+func applyNodeChaos(ctx context.Context, clientset *kubernetes.Clientset, name string, chaosType ChaosType, runID string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if _, ok := node.Annotations[nodeChaosAnnotation]; ok {
+			return fmt.Errorf("node is already under chaos")
+		}
+
+		data, err := json.Marshal(nodeChaosMarker{Type: chaosType, Unschedulable: node.Spec.Unschedulable})
+		if err != nil {
+			return err
+		}
+		if node.Annotations == nil {
+			node.Annotations = map[string]string{}
+		}
+		node.Annotations[nodeChaosAnnotation] = string(data)
+		if runID != "" {
+			node.Annotations[runAnnotation(nodeChaosAnnotation)] = runID
+		}
+
+		if chaosType == ChaosTypeNodeTaint {
+			node.Spec.Taints = append(node.Spec.Taints, v1.Taint{
+				Key:    nodeChaosTaintKey,
+				Value:  "true",
+				Effect: v1.TaintEffectNoExecute,
+			})
+		} else {
+			node.Spec.Unschedulable = true
+		}
+		_, err = clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// restoreNode restores the schedulability recorded in the node's marker,
+// removes the kubechaos taint and clears the marker
+func restoreNode(ctx context.Context, clientset *kubernetes.Clientset, name string) error {
+	fmt.Printf("🔧 Restoring node: %s\n", name)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		value, ok := node.Annotations[nodeChaosAnnotation]
+		if !ok {
+			return nil
+		}
+
+		var marker nodeChaosMarker
+		if err := json.Unmarshal([]byte(value), &marker); err != nil {
+			return fmt.Errorf("malformed node chaos annotation: %v", err)
+		}
+		node.Spec.Unschedulable = marker.Unschedulable
+		var taints []v1.Taint
+		for _, taint := range node.Spec.Taints {
+			if taint.Key != nodeChaosTaintKey {
+				taints = append(taints, taint)
+			}
+		}
+		node.Spec.Taints = taints
+		delete(node.Annotations, nodeChaosAnnotation)
+		delete(node.Annotations, runAnnotation(nodeChaosAnnotation))
+
+		_, err = clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		fmt.Printf("❌ Failed to restore node %s: %v\n", name, err)
+		return err
+	}
+	fmt.Printf("✅ Node restored: %s\n", name)
+	return nil
+}
+
+// drainablePods returns the pods a drain evicts from the node. DaemonSet and
+// static pods stay, and so do the pods pod chaos would not touch: pods in system,
+// denied (-deny-namespaces) or excluded namespaces, opted-out pods and, in strict
+// mode, pods that did not opt in.
+func drainablePods(ctx context.Context, clientset *kubernetes.Clientset, nodeName string, config ChaosConfig) ([]v1.Pod, error) {
+	namespaces, _, err := targeting.ResolveNamespaces(ctx, clientset, targeting.NamespaceCriteria{
+		Names: []string{"*"},
+		Deny:  config.NamespaceSelection.Deny,
+	})
+	if err != nil {
+		return nil, err
+	}
+	allowed := map[string]bool{}
+	for _, namespace := range namespaces {
+		allowed[namespace] = true
+	}
+	criteria := targeting.Criteria{ChaosType: string(config.Type), RequireOptIn: config.Strict}
+
+	pods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: "spec.nodeName=" + nodeName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods on node %s: %v", nodeName, err)
+	}
+
+	var drainable []v1.Pod
+	for _, pod := range pods.Items {
+		if owner := metav1.GetControllerOf(&pod); owner != nil && owner.Kind == "DaemonSet" {
+			continue
+		}
+		if _, mirror := pod.Annotations[v1.MirrorPodAnnotationKey]; mirror {
+			continue
+		}
+		if !allowed[pod.Namespace] || targeting.ExclusionReason(pod, criteria) != "" {
+			continue
+		}
+		drainable = append(drainable, pod)
+	}
+	return drainable, nil
+}
+
+// drainNode evicts the drainable pods of a cordoned node. Evictions refused by
+// a PodDisruptionBudget are skipped; -ignore-pdb deletes the pods instead.
+func drainNode(ctx context.Context, clientset *kubernetes.Clientset, nodeName string, config ChaosConfig) {
+	pods, err := drainablePods(ctx, clientset, nodeName, config)
+	if err != nil {
+		fmt.Printf("❌ Failed to drain node %s: %v\n", nodeName, err)
+		return
+	}
+
+	evicted, blocked := 0, 0
+	for _, pod := range pods {
+		if ctx.Err() != nil {
+			break
+		}
+		err := deletePod(ctx, clientset, pod, config.IgnorePDB)
+		switch {
+		case apierrors.IsTooManyRequests(err):
+			fmt.Printf("🛡️  Eviction of pod %s/%s blocked by a PodDisruptionBudget, skipping\n", pod.Namespace, pod.Name)
+			blocked++
+		case err != nil:
+			fmt.Printf("❌ Failed to evict pod %s/%s: %v\n", pod.Namespace, pod.Name, err)
+		default:
+			evicted++
+		}
+	}
+	fmt.Printf("✅ Drained node %s: %d/%d pods evicted, %d blocked\n", nodeName, evicted, len(pods), blocked)
+}
+
+// CleanupNodeChaos restores every node the run left cordoned or tainted. An
+// empty chaosType restores the nodes of every node chaos type, an empty runID
+// those of every run.
+func CleanupNodeChaos(ctx context.Context, clientset *kubernetes.Clientset, chaosType ChaosType, runID string) error {
+	fmt.Println("🧹 Cleaning up node chaos")
+
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	cleaned := 0
+	var failed []string
+	for _, node := range nodes.Items {
+		value, ok := node.Annotations[nodeChaosAnnotation]
+		if !ok || !ownedByRun(node.Annotations, nodeChaosAnnotation, runID) {
+			continue
+		}
+		var marker nodeChaosMarker
+		if err := json.Unmarshal([]byte(value), &marker); err == nil && chaosType != "" && marker.Type != chaosType {
+			continue
+		}
+		if err := restoreNode(ctx, clientset, node.Name); err != nil {
+			failed = append(failed, node.Name)
+			continue
+		}
+		cleaned++
+	}
+
+	fmt.Printf("✅ Cleaned up node chaos on %d nodes\n", cleaned)
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to restore nodes %v", failed)
+	}
+	return nil
+}
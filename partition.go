@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iamkrati22/kubechaos/targeting"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// partitionAnnotation marks pods that currently have partition rules installed
+// by kubechaos and holds the container they were installed in
+const partitionAnnotation = "kubechaos.io/partition"
+
+// partitionChain is the iptables chain holding the partition rules inside a pod
+const partitionChain = "KUBECHAOS-PARTITION"
+
+// partitionPidFile holds the PID of the in-pod revert watchdog
+const partitionPidFile = "/tmp/.kubechaos-partition.pid"
+
+func init() {
+	RegisterInjector(&funcInjector{
+		name:     ChaosTypeNetworkPartition,
+		validate: validatePartition,
+		inject:   injectPartition,
+		revert: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return forEachTargetNamespace(ctx, env.Clientset, config, func(namespace string) error {
+				return CleanupPartitions(ctx, env.RestConfig, env.Clientset, namespace, config.RunID)
+			})
+		},
+	}, "Cut traffic between two pod groups, or a pod group and CIDRs (in-pod iptables)")
+}
+
+// iptablesInstallCommand makes sure the iptables binaries are available inside the container
+const iptablesInstallCommand = "command -v iptables >/dev/null 2>&1 || " +
+	"(apk add --no-cache iptables ip6tables || (apt-get update && apt-get install -y iptables) || yum install -y iptables) >/dev/null 2>&1"
+
+// validatePartition checks the settings specific to network-partition chaos
+func validatePartition(config ChaosConfig) error {
+	if config.Network.PeerSelector == nil && len(config.Network.Destinations) == 0 {
+		return fmt.Errorf("network partition needs peer labels or destination CIDRs")
+	}
+	if config.Network.PeerSelector != nil && config.Network.PeerSelector.Empty() {
+		return fmt.Errorf("network partition peer labels must not select every pod")
+	}
+	return nil
+}
+
+// iptablesBinary returns the iptables binary handling the address or CIDR
+func iptablesBinary(address string) string {
+	if strings.Contains(address, ":") {
+		return "ip6tables"
+	}
+	return "iptables"
+}
+
+// partitionRemoveCommand creates the command that removes the partition chain
+func partitionRemoveCommand() string {
+	var cmds []string
+	for _, bin := range []string{"iptables", "ip6tables"} {
+		cmds = append(cmds, fmt.Sprintf("%s -D INPUT -j %s 2>/dev/null; %s -D OUTPUT -j %s 2>/dev/null; %s -F %s 2>/dev/null; %s -X %s 2>/dev/null",
+			bin, partitionChain, bin, partitionChain, bin, partitionChain, bin, partitionChain))
+	}
+	return strings.Join(cmds, "; ") + "; true"
+}
+
+// generatePartitionRevertCommand creates the command that removes the partition chain and its watchdog
+func generatePartitionRevertCommand() string {
+	return fmt.Sprintf("(kill $(cat %s) 2>/dev/null; rm -f %s); ", partitionPidFile, partitionPidFile) + partitionRemoveCommand()
+}
+
+// generatePartitionCommand creates the command that drops all traffic from and to
+// the addresses inside a pod. A watchdog removes the rules even if kubechaos dies.
+func generatePartitionCommand(addresses []string, duration time.Duration) string {
+	cmd := iptablesInstallCommand
+	chains := map[string]bool{}
+	for _, address := range addresses {
+		bin := iptablesBinary(address)
+		if !chains[bin] {
+			chains[bin] = true
+			cmd += fmt.Sprintf(" && (%s -N %s 2>/dev/null || true) && %s -I INPUT -j %s && %s -I OUTPUT -j %s",
+				bin, partitionChain, bin, partitionChain, bin, partitionChain)
+		}
+		cmd += fmt.Sprintf(" && %s -A %s -s %s -j DROP && %s -A %s -d %s -j DROP",
+			bin, partitionChain, address, bin, partitionChain, address)
+	}
+	cmd += fmt.Sprintf(" && (nohup sh -c 'sleep %d; %s' >/dev/null 2>&1 & echo $! > %s)",
+		int((duration + 30*time.Second).Seconds()), partitionRemoveCommand(), partitionPidFile)
+	return cmd
+}
+
+// partitionSide is one side of a partition: the pods whose traffic to the
+// other side is dropped, and the addresses of the other side
+type partitionSide struct {
+	pods      []v1.Pod
+	addresses []string
+}
+
+// findPartitionPeers selects the peers among the pods matching the peer labels in
+// the target namespaces, leaving out the pods of the first group. The peer side
+// is bounded by the same target count and strategy as the first group.
+func findPartitionPeers(ctx context.Context, clientset *kubernetes.Clientset, config ChaosConfig, group []v1.Pod) ([]v1.Pod, error) {
+	namespaces, err := resolveTargetNamespaces(ctx, clientset, config)
+	if err != nil {
+		return nil, err
+	}
+	criteria := targetCriteria(namespaces, config)
+	criteria.LabelSelector = config.Network.PeerSelector
+	candidates, err := targeting.FindCandidates(ctx, clientset, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	inGroup := map[string]bool{}
+	for _, pod := range group {
+		inGroup[pod.Namespace+"/"+pod.Name] = true
+	}
+	var peers []v1.Pod
+	for _, pod := range candidates.Pods {
+		if !inGroup[pod.Namespace+"/"+pod.Name] {
+			peers = append(peers, pod)
+		}
+	}
+	if len(peers) == 0 {
+		return nil, fmt.Errorf("no peer pods match %q in namespace %s", config.Network.PeerSelector.String(), config.namespaceScope())
+	}
+	candidates.Pods = peers
+	return candidates.Select(config.random(), targetCount(config, len(peers)), config.Selection), nil
+}
+
+// podAddresses returns the IP addresses of the pods
+func podAddresses(pods []v1.Pod) []string {
+	var addresses []string
+	for _, pod := range pods {
+		for _, ip := range pod.Status.PodIPs {
+			addresses = append(addresses, ip.IP)
+		}
+	}
+	return addresses
+}
+
+// injectPartition cuts the traffic between every target pod and every peer pod
+// (or destination CIDR) for the configured duration, then restores it
+func injectPartition(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+	clientset := env.Clientset
+	fmt.Printf("✂️  Applying NETWORK PARTITION chaos to namespace: %s\n", config.namespaceScope())
+
+	group, err := selectTargets(ctx, clientset, config)
+	if err != nil {
+		return err
+	}
+
+	// The rules go into both groups, so the partition holds even where one side cannot run iptables
+	sides := []partitionSide{{pods: group, addresses: append([]string{}, config.Network.Destinations...)}}
+	var peers []v1.Pod
+	if config.Network.PeerSelector != nil {
+		if peers, err = findPartitionPeers(ctx, clientset, config, group); err != nil {
+			return err
+		}
+		sides[0].addresses = append(sides[0].addresses, podAddresses(peers)...)
+		sides = append(sides, partitionSide{pods: peers, addresses: podAddresses(group)})
+	}
+
+	var pairs []string
+	for _, pod := range group {
+		for _, peer := range peers {
+			pairs = append(pairs, fmt.Sprintf("%s/%s ↔ %s/%s", pod.Namespace, pod.Name, peer.Namespace, peer.Name))
+		}
+		for _, cidr := range config.Network.Destinations {
+			pairs = append(pairs, fmt.Sprintf("%s/%s ↔ %s", pod.Namespace, pod.Name, cidr))
+		}
+	}
+
+	if config.DryRun {
+		fmt.Println("🔍 DRY RUN MODE - No traffic will be cut")
+		fmt.Printf("📋 Would partition %d pairs:\n", len(pairs))
+		for i, pair := range pairs {
+			fmt.Printf("  %d. %s\n", i+1, pair)
+		}
+		return nil
+	}
+
+	var partitioned []v1.Pod
+	for _, side := range sides {
+		if len(side.addresses) == 0 {
+			continue
+		}
+		cmd := generatePartitionCommand(side.addresses, config.Duration)
+		for _, pod := range side.pods {
+			if ctx.Err() != nil {
+				break
+			}
+			if applyPartition(ctx, env.RestConfig, clientset, pod, cmd, config.RunID) {
+				partitioned = append(partitioned, pod)
+			}
+		}
+	}
+
+	if len(partitioned) == 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to install partition rules in any pod")
+	}
+	fmt.Printf("✂️  Partitioned %d pairs (rules in %d pods):\n", len(pairs), len(partitioned))
+	for _, pair := range pairs {
+		fmt.Printf("  %s\n", pair)
+	}
+
+	fmt.Printf("⏳ Holding network partition for %s...\n", config.Duration)
+	if holdChaos(ctx, config.Duration) != nil {
+		// The markers stay on the pods so the rollback can remove the rules
+		fmt.Println("🛑 Network partition interrupted")
+		return ctx.Err()
+	}
+
+	// Only the pods this run partitioned; pods skipped as already partitioned belong to another run
+	var failed []string
+	for _, pod := range partitioned {
+		if err := revertPartition(ctx, env.RestConfig, clientset, pod.Namespace, pod.Name, config.RunID); err != nil {
+			failed = append(failed, pod.Namespace+"/"+pod.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to remove partition rules from pods %v", failed)
+	}
+	return nil
+}
+
+// applyPartition installs the partition rules in the first container of the pod
+func applyPartition(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, pod v1.Pod, cmd, runID string) bool {
+	if len(pod.Spec.Containers) == 0 {
+		fmt.Printf("⚠️  Pod %s has no containers, skipping\n", pod.Name)
+		return false
+	}
+	if _, busy := pod.Annotations[partitionAnnotation]; busy {
+		fmt.Printf("⚠️  Pod %s is already partitioned, skipping\n", pod.Name)
+		return false
+	}
+	container := pod.Spec.Containers[0].Name
+
+	fmt.Printf("✂️  Installing partition rules in pod: %s (container: %s)\n", pod.Name, container)
+	// Record the fault before injecting it so -cleanup can always find it
+	if err := markPod(ctx, clientset, pod.Namespace, pod.Name, partitionAnnotation, container, runID); err != nil {
+		fmt.Printf("❌ Failed to annotate pod %s: %v\n", pod.Name, err)
+		return false
+	}
+	if err := execInPod(ctx, config, clientset, pod.Namespace, pod.Name, container, cmd); err != nil {
+		fmt.Printf("❌ Failed to install partition rules in pod %s: %v\n", pod.Name, err)
+		revertPartition(ctx, config, clientset, pod.Namespace, pod.Name, runID)
+		return false
+	}
+	return true
+}
+
+// revertPartition removes the partition rules from the pod and clears its marker
+// annotation. Pods partitioned by another run are left alone, unless runID is empty.
+func revertPartition(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, podName, runID string) error {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	container, ok := pod.Annotations[partitionAnnotation]
+	if !ok || !ownedByRun(pod.Annotations, partitionAnnotation, runID) {
+		return nil
+	}
+
+	fmt.Printf("🔧 Removing partition rules from pod: %s\n", podName)
+	if err := execInPod(ctx, config, clientset, namespace, podName, container, generatePartitionRevertCommand()); err != nil {
+		fmt.Printf("❌ Failed to remove partition rules from pod %s: %v\n", podName, err)
+		return err
+	}
+	if err := patchPodAnnotation(ctx, clientset, namespace, podName, partitionAnnotation, nil); err != nil {
+		fmt.Printf("⚠️  Failed to remove partition annotation from pod %s: %v\n", podName, err)
+		return err
+	}
+	return nil
+}
+
+// CleanupPartitions removes the partition rules the run left behind in the
+// namespace, or those of any run when runID is empty
+func CleanupPartitions(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, runID string) error {
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	cleaned := 0
+	var failed []string
+	for _, pod := range pods.Items {
+		if _, ok := pod.Annotations[partitionAnnotation]; !ok || !ownedByRun(pod.Annotations, partitionAnnotation, runID) {
+			continue
+		}
+		if err := revertPartition(ctx, config, clientset, namespace, pod.Name, runID); err != nil {
+			failed = append(failed, pod.Name)
+			continue
+		}
+		cleaned++
+	}
+	if cleaned > 0 {
+		fmt.Printf("✅ Removed partition rules from %d pods\n", cleaned)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to remove partition rules from pods %v", failed)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iamkrati22/kubechaos/targeting"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+func init() {
+	RegisterInjector(&funcInjector{
+		name:   ChaosTypePodDelete,
+		inject: injectPodDelete,
+	}, "Evict random pods, honouring PodDisruptionBudgets (use -dry-run to preview)")
+}
+
+// injectPodDelete deletes a random subset of the eligible pods, never taking an
+// owner below its minimum available pods
+func injectPodDelete(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+	candidates, err := findTargets(ctx, env.Clientset, config)
+	if err != nil {
+		return err
+	}
+
+	count := targetCount(config, len(candidates.Pods))
+	var limits []targeting.Limit
+	if config.MinAvailable != nil {
+		budgets, err := disruptionBudgets(ctx, env.Clientset, candidates.Pods, *config.MinAvailable)
+		if err != nil {
+			return err
+		}
+		limits = append(limits, targeting.Limit{Key: controllerKey, Max: budgets})
+	}
+
+	selectedPods := candidates.Select(config.random(), count, config.Selection, limits...)
+	if len(selectedPods) == 0 {
+		if config.MinAvailable != nil {
+			return fmt.Errorf("no pod can be deleted without going below %s available", config.MinAvailable.String())
+		}
+		return fmt.Errorf("no pod selected in namespace %s", config.namespaceScope())
+	}
+
+	if err := applyPodDeleteChaos(ctx, env.Clientset, selectedPods, config); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// parseMinAvailable parses a minimum available count, either "N" or "X%"
+func parseMinAvailable(value string) (*intstr.IntOrString, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if percent := strings.TrimSuffix(value, "%"); percent != value {
+		n, err := strconv.Atoi(percent)
+		if err != nil || n < 0 || n > 100 {
+			return nil, fmt.Errorf("invalid min-available %q: percentage must be between 0%% and 100%%", value)
+		}
+	} else if n, err := strconv.Atoi(value); err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid min-available %q: must be a non-negative number or a percentage", value)
+	}
+	minAvailable := intstr.Parse(value)
+	return &minAvailable, nil
+}
+
+// controllerKey identifies the controller owning a pod, or returns "" for pods without one
+func controllerKey(pod v1.Pod) string {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return ""
+	}
+	return pod.Namespace + "/" + owner.Kind + "/" + owner.Name
+}
+
+// disruptionBudgets computes how many pods of every ReplicaSet and StatefulSet
+// owning a candidate can be deleted while minAvailable of them stay Ready.
+// Pods of other owners are not limited.
+func disruptionBudgets(ctx context.Context, clientset *kubernetes.Clientset, pods []v1.Pod, minAvailable intstr.IntOrString) (map[string]int, error) {
+	budgets := map[string]int{}
+	for _, pod := range pods {
+		key := controllerKey(pod)
+		if _, seen := budgets[key]; key == "" || seen {
+			continue
+		}
+
+		owner := metav1.GetControllerOf(&pod)
+		var desired, ready int32
+		switch owner.Kind {
+		case "ReplicaSet":
+			rs, err := clientset.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get owner of pod %s: %v", pod.Name, err)
+			}
+			desired, ready = replicasOrOne(rs.Spec.Replicas), rs.Status.ReadyReplicas
+		case "StatefulSet":
+			sts, err := clientset.AppsV1().StatefulSets(pod.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get owner of pod %s: %v", pod.Name, err)
+			}
+			desired, ready = replicasOrOne(sts.Spec.Replicas), sts.Status.ReadyReplicas
+		default:
+			continue
+		}
+
+		required, err := intstr.GetScaledValueFromIntOrPercent(&minAvailable, int(desired), true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid min-available %q: %v", minAvailable.String(), err)
+		}
+		budget := int(ready) - required
+		if budget < 0 {
+			budget = 0
+		}
+		budgets[key] = budget
+		fmt.Printf("🛡️  %s: %d/%d ready, keeping %d, up to %d may be deleted\n", key, ready, desired, required, budget)
+	}
+	return budgets, nil
+}
+
+// replicasOrOne returns the desired replicas, which default to 1 when unset
+func replicasOrOne(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// applyPodDeleteChaos applies pod deletion chaos, and fails when no pod could be deleted
+func applyPodDeleteChaos(ctx context.Context, clientset *kubernetes.Clientset, selectedPods []v1.Pod, config ChaosConfig) error {
+	action := "Evicting"
+	if config.IgnorePDB {
+		action = "Deleting"
+	}
+
+	if config.DryRun {
+		fmt.Println("🔍 DRY RUN MODE - No pods will be deleted")
+		if config.IgnorePDB {
+			fmt.Printf("📋 Would delete %d pods, ignoring PodDisruptionBudgets:\n", len(selectedPods))
+		} else {
+			fmt.Printf("📋 Would evict %d pods:\n", len(selectedPods))
+		}
+		for i, pod := range selectedPods {
+			fmt.Printf("  %d. %s/%s (Status: %s)\n", i+1, pod.Namespace, pod.Name, pod.Status.Phase)
+		}
+		return nil
+	}
+
+	// Delete the selected pods
+	deletedPods := []string{}
+	blocked := 0
+	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			fmt.Println("🛑 Pod deletion interrupted")
+			break
+		}
+		fmt.Printf("💀 %s pod %d/%d: %s/%s\n", action, i+1, len(selectedPods), pod.Namespace, pod.Name)
+		err := deletePod(ctx, clientset, pod, config.IgnorePDB)
+		switch {
+		case apierrors.IsTooManyRequests(err):
+			fmt.Printf("🛡️  Eviction of pod %s blocked by a PodDisruptionBudget, skipping\n", pod.Name)
+			blocked++
+		case err != nil:
+			fmt.Printf("❌ Failed to delete pod %s: %v\n", pod.Name, err)
+		default:
+			deletedPods = append(deletedPods, pod.Namespace+"/"+pod.Name)
+		}
+	}
+
+	fmt.Printf("✅ Successfully deleted %d/%d pods!\n", len(deletedPods), len(selectedPods))
+	fmt.Printf("📊 Summary: Deleted pods %v from namespace '%s'\n", deletedPods, config.namespaceScope())
+	if len(deletedPods) == 0 && ctx.Err() == nil {
+		return fmt.Errorf("no pod could be evicted (%d blocked by PodDisruptionBudgets)", blocked)
+	}
+	return nil
+}
+
+// deletePod evicts a pod through the Eviction API, so PodDisruptionBudgets are
+// honoured, or deletes it directly when ignorePDB is set
+func deletePod(ctx context.Context, clientset *kubernetes.Clientset, pod v1.Pod, ignorePDB bool) error {
+	if ignorePDB {
+		return clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+	}
+	eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+	return clientset.CoreV1().Pods(pod.Namespace).EvictV1(ctx, eviction)
+}
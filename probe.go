@@ -0,0 +1,415 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iamkrati22/kubechaos/targeting"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ProbePhase is a point of an experiment at which probes are evaluated
+type ProbePhase string
+
+const (
+	ProbePhaseBefore ProbePhase = "before" // Before the fault is injected
+	ProbePhaseDuring ProbePhase = "during" // Continuously while the fault is active
+	ProbePhaseAfter  ProbePhase = "after"  // After the fault was reverted, until the system recovered
+)
+
+// Defaults used when a steady state leaves them out
+const (
+	defaultProbeTimeout    = 10 * time.Second
+	defaultProbeInterval   = 10 * time.Second
+	defaultRecoveryTimeout = time.Minute
+)
+
+// SteadyState is the hypothesis an experiment is judged against
+type SteadyState struct {
+	Probes          []Probe
+	Interval        time.Duration // Time between checks during injection and recovery
+	RecoveryTimeout time.Duration // How long the after probes may take to pass again
+}
+
+// Probe checks one aspect of the steady state. Exactly one of the checks is set.
+type Probe struct {
+	Name         string
+	Phases       []ProbePhase // Empty means every phase
+	Timeout      time.Duration
+	HTTP         *HTTPProbe
+	Resource     *ResourceProbe
+	PodReadiness *PodReadinessProbe
+	Command      *CommandProbe
+}
+
+// HTTPProbe sends a GET request and checks the response
+type HTTPProbe struct {
+	URL          string `yaml:"url"`
+	ExpectStatus int    `yaml:"expectStatus"` // Defaults to 200
+	ExpectBody   string `yaml:"expectBody"`   // Substring the body must contain
+}
+
+// ResourceProbe compares a field of a workload, or checks one of its conditions
+type ResourceProbe struct {
+	Kind      string  `yaml:"kind"` // Deployment, StatefulSet, DaemonSet or ReplicaSet
+	Namespace string  `yaml:"namespace"`
+	Name      string  `yaml:"name"`
+	Field     string  `yaml:"field"`    // e.g. status.availableReplicas
+	Operator  string  `yaml:"operator"` // >=, >, ==, !=, <=, <
+	Value     float64 `yaml:"value"`
+	Condition string  `yaml:"condition"` // Condition type that must be True, e.g. Available
+}
+
+// PodReadinessProbe checks the share of Ready pods matching a selector
+type PodReadinessProbe struct {
+	Namespace       string `yaml:"namespace"`
+	Labels          string `yaml:"labels"`
+	MinReadyPercent int    `yaml:"minReadyPercent"`
+}
+
+// CommandProbe runs a local shell command and checks its exit code
+type CommandProbe struct {
+	Command        string `yaml:"command"`
+	ExpectExitCode int    `yaml:"expectExitCode"`
+}
+
+// SteadyStateSpec is the file representation of a steady state
+type SteadyStateSpec struct {
+	Interval        string      `yaml:"interval"`
+	RecoveryTimeout string      `yaml:"recoveryTimeout"`
+	Probes          []ProbeSpec `yaml:"probes"`
+}
+
+// ProbeSpec is the file representation of a probe
+type ProbeSpec struct {
+	Name         string             `yaml:"name"`
+	Phases       []string           `yaml:"phases"`
+	Timeout      string             `yaml:"timeout"`
+	HTTP         *HTTPProbe         `yaml:"http"`
+	Resource     *ResourceProbe     `yaml:"resource"`
+	PodReadiness *PodReadinessProbe `yaml:"podReadiness"`
+	Command      *CommandProbe      `yaml:"command"`
+}
+
+// resourceGetters fetch the workload kinds a ResourceProbe supports
+var resourceGetters = map[string]func(env ChaosEnv, ctx context.Context, namespace, name string) (runtime.Object, error){
+	"Deployment": func(env ChaosEnv, ctx context.Context, namespace, name string) (runtime.Object, error) {
+		return env.Clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+	"StatefulSet": func(env ChaosEnv, ctx context.Context, namespace, name string) (runtime.Object, error) {
+		return env.Clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+	"DaemonSet": func(env ChaosEnv, ctx context.Context, namespace, name string) (runtime.Object, error) {
+		return env.Clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+	"ReplicaSet": func(env ChaosEnv, ctx context.Context, namespace, name string) (runtime.Object, error) {
+		return env.Clientset.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	},
+}
+
+// compareOperators are the operators a ResourceProbe accepts
+var compareOperators = map[string]func(a, b float64) bool{
+	">=": func(a, b float64) bool { return a >= b },
+	">":  func(a, b float64) bool { return a > b },
+	"==": func(a, b float64) bool { return a == b },
+	"!=": func(a, b float64) bool { return a != b },
+	"<=": func(a, b float64) bool { return a <= b },
+	"<":  func(a, b float64) bool { return a < b },
+}
+
+// parseSteadyState converts the file representation into a SteadyState.
+// Problems carry the path of the offending field below "steadyState".
+func parseSteadyState(spec SteadyStateSpec) (SteadyState, []experimentProblem) {
+	steadyState := SteadyState{Interval: defaultProbeInterval, RecoveryTimeout: defaultRecoveryTimeout}
+	var problems []experimentProblem
+	add := func(msg string, field ...string) {
+		problems = append(problems, experimentProblem{append([]string{"steadyState"}, field...), msg})
+	}
+	parseDuration := func(value string, fallback time.Duration, field ...string) time.Duration {
+		if value == "" {
+			return fallback
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			add(fmt.Sprintf("invalid %s %q", field[len(field)-1], value), field...)
+			return fallback
+		}
+		return parsed
+	}
+
+	steadyState.Interval = parseDuration(spec.Interval, defaultProbeInterval, "interval")
+	steadyState.RecoveryTimeout = parseDuration(spec.RecoveryTimeout, defaultRecoveryTimeout, "recoveryTimeout")
+	if len(spec.Probes) == 0 {
+		add("steady state has no probes", "probes")
+	}
+
+	for i, probeSpec := range spec.Probes {
+		index := fmt.Sprint(i)
+		probe := Probe{
+			Name:         probeSpec.Name,
+			Timeout:      parseDuration(probeSpec.Timeout, defaultProbeTimeout, "probes", index, "timeout"),
+			HTTP:         probeSpec.HTTP,
+			Resource:     probeSpec.Resource,
+			PodReadiness: probeSpec.PodReadiness,
+			Command:      probeSpec.Command,
+		}
+		if probe.Name == "" {
+			probe.Name = fmt.Sprintf("probe %d", i+1)
+		}
+		for _, phase := range probeSpec.Phases {
+			switch ProbePhase(phase) {
+			case ProbePhaseBefore, ProbePhaseDuring, ProbePhaseAfter:
+				probe.Phases = append(probe.Phases, ProbePhase(phase))
+			default:
+				add(fmt.Sprintf("unknown probe phase %q (available: before, during, after)", phase), "probes", index, "phases")
+			}
+		}
+		if err := probe.validate(); err != nil {
+			add(fmt.Sprintf("%s: %v", probe.Name, err), "probes", index)
+		}
+		steadyState.Probes = append(steadyState.Probes, probe)
+	}
+	return steadyState, problems
+}
+
+// validate checks that exactly one check is configured and that it is complete
+func (p Probe) validate() error {
+	checks := 0
+	for _, set := range []bool{p.HTTP != nil, p.Resource != nil, p.PodReadiness != nil, p.Command != nil} {
+		if set {
+			checks++
+		}
+	}
+	if checks != 1 {
+		return fmt.Errorf("exactly one of http, resource, podReadiness or command must be set")
+	}
+
+	switch {
+	case p.HTTP != nil:
+		parsed, err := url.Parse(p.HTTP.URL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("invalid url %q", p.HTTP.URL)
+		}
+	case p.Resource != nil:
+		if _, ok := resourceGetters[p.Resource.Kind]; !ok {
+			return fmt.Errorf("unsupported resource kind %q (available: Deployment, StatefulSet, DaemonSet, ReplicaSet)", p.Resource.Kind)
+		}
+		if p.Resource.Namespace == "" || p.Resource.Name == "" {
+			return fmt.Errorf("resource namespace and name must be set")
+		}
+		if (p.Resource.Field == "") == (p.Resource.Condition == "") {
+			return fmt.Errorf("exactly one of field or condition must be set")
+		}
+		if _, ok := compareOperators[p.Resource.Operator]; p.Resource.Field != "" && !ok {
+			return fmt.Errorf("invalid operator %q (available: >=, >, ==, !=, <=, <)", p.Resource.Operator)
+		}
+	case p.PodReadiness != nil:
+		if p.PodReadiness.Namespace == "" {
+			return fmt.Errorf("podReadiness namespace must be set")
+		}
+		if _, err := parseLabelSelector(p.PodReadiness.Labels); err != nil {
+			return err
+		}
+		if p.PodReadiness.MinReadyPercent < 0 || p.PodReadiness.MinReadyPercent > 100 {
+			return fmt.Errorf("minReadyPercent must be between 0 and 100, got %d", p.PodReadiness.MinReadyPercent)
+		}
+	case p.Command != nil:
+		if strings.TrimSpace(p.Command.Command) == "" {
+			return fmt.Errorf("command must not be empty")
+		}
+	}
+	return nil
+}
+
+// runsIn reports whether the probe is evaluated in the phase
+func (p Probe) runsIn(phase ProbePhase) bool {
+	if len(p.Phases) == 0 {
+		return true
+	}
+	for _, probePhase := range p.Phases {
+		if probePhase == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// Check evaluates the probe once; a nil error means the probe passed
+func (p Probe) Check(ctx context.Context, env ChaosEnv) error {
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	switch {
+	case p.HTTP != nil:
+		return p.HTTP.check(ctx)
+	case p.Resource != nil:
+		return p.Resource.check(ctx, env)
+	case p.PodReadiness != nil:
+		return p.PodReadiness.check(ctx, env)
+	case p.Command != nil:
+		return p.Command.check(ctx)
+	}
+	return fmt.Errorf("probe has no check")
+}
+
+func (h *HTTPProbe) check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	expectStatus := h.ExpectStatus
+	if expectStatus == 0 {
+		expectStatus = http.StatusOK
+	}
+	if resp.StatusCode != expectStatus {
+		return fmt.Errorf("got status %d, expected %d", resp.StatusCode, expectStatus)
+	}
+	if h.ExpectBody != "" {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		if err != nil {
+			return fmt.Errorf("failed to read body: %v", err)
+		}
+		if !strings.Contains(string(body), h.ExpectBody) {
+			return fmt.Errorf("body does not contain %q", h.ExpectBody)
+		}
+	}
+	return nil
+}
+
+func (r *ResourceProbe) check(ctx context.Context, env ChaosEnv) error {
+	obj, err := resourceGetters[r.Kind](env, ctx, r.Namespace, r.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get %s %s/%s: %v", r.Kind, r.Namespace, r.Name, err)
+	}
+	fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+
+	if r.Condition != "" {
+		conditions, _, _ := unstructured.NestedSlice(fields, "status", "conditions")
+		for _, condition := range conditions {
+			condition, _ := condition.(map[string]interface{})
+			if condition["type"] == r.Condition {
+				if condition["status"] != "True" {
+					return fmt.Errorf("condition %s is %v: %v", r.Condition, condition["status"], condition["message"])
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("condition %s not reported", r.Condition)
+	}
+
+	// Fields the API leaves out, like a zero availableReplicas, count as 0
+	var actual float64
+	value, _, _ := unstructured.NestedFieldNoCopy(fields, strings.Split(r.Field, ".")...)
+	switch value := value.(type) {
+	case int64:
+		actual = float64(value)
+	case float64:
+		actual = value
+	case nil:
+	default:
+		return fmt.Errorf("field %s is not a number", r.Field)
+	}
+	if !compareOperators[r.Operator](actual, r.Value) {
+		return fmt.Errorf("%s is %v, expected %s %v", r.Field, actual, r.Operator, r.Value)
+	}
+	return nil
+}
+
+func (r *PodReadinessProbe) check(ctx context.Context, env ChaosEnv) error {
+	selector, err := parseLabelSelector(r.Labels)
+	if err != nil {
+		return err
+	}
+	candidates, err := targeting.FindCandidates(ctx, env.Clientset, targeting.Criteria{
+		Namespaces:    []string{r.Namespace},
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return err
+	}
+	if len(candidates.Pods) == 0 {
+		return fmt.Errorf("no pods match %q in namespace %s", r.Labels, r.Namespace)
+	}
+
+	ready := 0
+	for _, pod := range candidates.Pods {
+		if targeting.IsPodReady(pod) {
+			ready++
+		}
+	}
+	percent := ready * 100 / len(candidates.Pods)
+	if percent < r.MinReadyPercent {
+		return fmt.Errorf("%d/%d pods ready (%d%%), expected at least %d%%", ready, len(candidates.Pods), percent, r.MinReadyPercent)
+	}
+	return nil
+}
+
+func (c *CommandProbe) check(ctx context.Context) error {
+	err := exec.CommandContext(ctx, "sh", "-c", c.Command).Run()
+	exitCode := 0
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return err
+		}
+		exitCode = exitErr.ExitCode()
+	}
+	if exitCode != c.ExpectExitCode {
+		return fmt.Errorf("exit code %d, expected %d", exitCode, c.ExpectExitCode)
+	}
+	return nil
+}
+
+// probesIn reports whether any probe is evaluated in the phase
+func (s SteadyState) probesIn(phase ProbePhase) bool {
+	for _, probe := range s.Probes {
+		if probe.runsIn(phase) {
+			return true
+		}
+	}
+	return false
+}
+
+// check evaluates every probe of the phase and returns the failures by probe name
+func (s SteadyState) check(ctx context.Context, env ChaosEnv, phase ProbePhase) map[string]error {
+	failures := map[string]error{}
+	for _, probe := range s.Probes {
+		if !probe.runsIn(phase) {
+			continue
+		}
+		if err := probe.Check(ctx, env); err != nil {
+			fmt.Printf("❌ Probe %s failed (%s): %v\n", probe.Name, phase, err)
+			failures[probe.Name] = err
+		}
+	}
+	return failures
+}
+
+// describeProbeFailures formats probe failures for error messages
+func describeProbeFailures(failures map[string]error) string {
+	var parts []string
+	for probe, err := range failures {
+		parts = append(parts, fmt.Sprintf("%s: %v", probe, err))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/iamkrati22/kubechaos/targeting"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// freezeStartTimeout bounds how long a freeze helper pod may take to stop the processes
+const freezeStartTimeout = 2 * time.Minute
+
+// helperDeleteTimeout bounds the deletion of a node helper pod, which also runs
+// after the injection was cancelled
+const helperDeleteTimeout = 30 * time.Second
+
+// containerPidsScript runs in a node helper pod and collects in $pids the host
+// PIDs of the processes whose cgroup belongs to $CONTAINER_ID and whose command
+// line matches $PROCESS_MATCH (every process when empty)
+const containerPidsScript = `pids=""
+for d in /proc/[0-9]*; do
+  grep -q "$CONTAINER_ID" "$d/cgroup" 2>/dev/null || continue
+  if [ -n "$PROCESS_MATCH" ] && ! tr '\0' ' ' < "$d/cmdline" | grep -Eq "$PROCESS_MATCH"; then continue; fi
+  pids="$pids ${d#/proc/}"
+done
+if [ -z "$pids" ]; then echo "no matching processes"; exit 1; fi
+`
+
+// freezeScript runs in the freeze helper pod, in the host PID namespace so that
+// it can also stop the PID 1 of the target container (the kernel ignores SIGSTOP
+// sent to a namespace's init from inside the namespace). It stops the selected
+// processes and continues them after $DURATION seconds or when the pod is deleted.
+const freezeScript = containerPidsScript + `trap 'kill -CONT $pids; exit 0' TERM INT
+kill -STOP $pids
+for p in $pids; do echo "frozen $p $(tr '\0' ' ' < /proc/$p/cmdline)"; done
+sleep "$DURATION" & wait $!
+kill -CONT $pids`
+
+func init() {
+	RegisterInjector(&funcInjector{
+		name:     ChaosTypePodFreeze,
+		validate: validateProcessMatch,
+		inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return ApplyPodFreezeChaos(ctx, env.Clientset, config)
+		},
+		revert: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return forEachTargetNamespace(ctx, env.Clientset, config, func(namespace string) error {
+				return deleteStressPods(ctx, env.Clientset, namespace, ChaosTypePodFreeze, config.RunID)
+			})
+		},
+	}, "Pause the processes of the target containers with SIGSTOP, then SIGCONT them")
+}
+
+// validateProcessMatch checks the process name/regex of the process chaos types
+func validateProcessMatch(config ChaosConfig) error {
+	if _, err := regexp.Compile(config.Process.Match); err != nil {
+		return fmt.Errorf("invalid process match %q: %v", config.Process.Match, err)
+	}
+	return nil
+}
+
+// describeProcessMatch describes the processes selected by the match for log output
+func describeProcessMatch(match string) string {
+	if match == "" {
+		return "all processes"
+	}
+	return fmt.Sprintf("processes matching %q", match)
+}
+
+// containerID returns the runtime ID of the running container of the pod, without
+// its "containerd://" style prefix, or "" if the container is not running
+func containerID(pod v1.Pod, container string) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container && status.State.Running != nil {
+			if i := strings.Index(status.ContainerID, "://"); i >= 0 {
+				return status.ContainerID[i+3:]
+			}
+			return status.ContainerID
+		}
+	}
+	return ""
+}
+
+// ApplyPodFreezeChaos stops the processes of the main container of the selected
+// pods for the configured duration, then continues them
+func ApplyPodFreezeChaos(ctx context.Context, clientset *kubernetes.Clientset, config ChaosConfig) error {
+	fmt.Printf("🧊 Applying POD FREEZE chaos to namespace: %s (%s)\n", config.namespaceScope(), describeProcessMatch(config.Process.Match))
+
+	selectedPods, err := selectTargets(ctx, clientset, config)
+	if err != nil {
+		return err
+	}
+
+	if config.DryRun {
+		fmt.Println("🔍 DRY RUN MODE - No process will be frozen")
+		fmt.Printf("📋 Would freeze %s in %d pods:\n", describeProcessMatch(config.Process.Match), len(selectedPods))
+		for i, pod := range selectedPods {
+			fmt.Printf("  %d. %s/%s\n", i+1, pod.Namespace, pod.Name)
+		}
+		return nil
+	}
+
+	frozen := 0
+	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			break
+		}
+		if len(pod.Spec.Containers) == 0 {
+			fmt.Printf("⚠️  Pod %s has no containers, skipping\n", pod.Name)
+			continue
+		}
+		container := pod.Spec.Containers[0].Name
+		id := containerID(pod, container)
+		if id == "" {
+			fmt.Printf("⚠️  Container %s of pod %s is not running, skipping\n", container, pod.Name)
+			continue
+		}
+
+		fmt.Printf("🧊 Freezing pod %d/%d: %s (container: %s, node: %s)\n", i+1, len(selectedPods), pod.Name, container, pod.Spec.NodeName)
+		if err := freezeContainer(ctx, clientset, pod, id, config); err != nil {
+			fmt.Printf("❌ Failed to freeze pod %s: %v\n", pod.Name, err)
+			continue
+		}
+		frozen++
+	}
+
+	if frozen == 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to freeze any pod")
+	}
+
+	// Keep the run going while the helper pods hold the processes, so it can be aborted
+	fmt.Printf("⏳ Holding pod freeze for %s...\n", config.Duration)
+	if holdChaos(ctx, config.Duration) != nil {
+		// The helper pods stay so the rollback can delete them, which continues the processes
+		fmt.Println("🛑 Pod freeze interrupted")
+		return ctx.Err()
+	}
+
+	return forEachTargetNamespace(ctx, clientset, config, func(namespace string) error {
+		return deleteStressPods(ctx, clientset, namespace, ChaosTypePodFreeze, config.RunID)
+	})
+}
+
+// createNodeHelper starts a privileged helper pod in the host PID namespace of
+// the node of the pod, running the script with the given environment. The helper
+// is labelled with the run ID so the rollback of the run can find it.
+func createNodeHelper(ctx context.Context, clientset *kubernetes.Clientset, pod v1.Pod, chaosType ChaosType, name, script, runID string, env []v1.EnvVar) (*v1.Pod, error) {
+	privileged := true
+	helper := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s-%d", name, pod.Name, time.Now().Unix()),
+			Namespace: pod.Namespace,
+			Labels: map[string]string{
+				targeting.ChaosTypeLabel: string(chaosType),
+				"target-pod":             pod.Name,
+				runIDLabel:               runID,
+			},
+		},
+		Spec: v1.PodSpec{
+			NodeName:    pod.Spec.NodeName,
+			HostPID:     true,
+			Tolerations: []v1.Toleration{{Operator: v1.TolerationOpExists}},
+			Containers: []v1.Container{
+				{
+					Name:            name,
+					Image:           "alpine:latest",
+					Command:         []string{"sh", "-c", script},
+					Env:             env,
+					SecurityContext: &v1.SecurityContext{Privileged: &privileged},
+				},
+			},
+			RestartPolicy: v1.RestartPolicyNever,
+		},
+	}
+
+	created, err := clientset.CoreV1().Pods(pod.Namespace).Create(ctx, helper, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create helper pod: %v", err)
+	}
+	return created, nil
+}
+
+// deleteNodeHelper deletes a node helper pod, logging when it cannot be deleted
+func deleteNodeHelper(clientset *kubernetes.Clientset, helper *v1.Pod) error {
+	ctx, cancel := context.WithTimeout(context.Background(), helperDeleteTimeout)
+	defer cancel()
+	err := clientset.CoreV1().Pods(helper.Namespace).Delete(ctx, helper.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		fmt.Printf("⚠️  Failed to delete helper pod %s/%s: %v (remove it with -cleanup)\n", helper.Namespace, helper.Name, err)
+		return err
+	}
+	return nil
+}
+
+// freezeContainer starts a helper pod on the node of the pod that stops the
+// container's processes, and reports them once they are stopped. The helper is
+// deleted again when the processes cannot be frozen, so it cannot freeze them late.
+func freezeContainer(ctx context.Context, clientset *kubernetes.Clientset, pod v1.Pod, id string, config ChaosConfig) (err error) {
+	created, err := createNodeHelper(ctx, clientset, pod, ChaosTypePodFreeze, "freeze", freezeScript, config.RunID, []v1.EnvVar{
+		{Name: "CONTAINER_ID", Value: id},
+		{Name: "PROCESS_MATCH", Value: config.Process.Match},
+		{Name: "DURATION", Value: fmt.Sprint(int(config.Duration.Seconds()))},
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			deleteNodeHelper(clientset, created)
+		}
+	}()
+
+	deadline := time.Now().Add(freezeStartTimeout)
+	for time.Now().Before(deadline) {
+		current, err := clientset.CoreV1().Pods(pod.Namespace).Get(ctx, created.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		switch current.Status.Phase {
+		case v1.PodRunning:
+			// The script logs the stopped processes right after stopping them
+			if logs := helperLogs(ctx, clientset, current); strings.Contains(logs, "frozen ") {
+				for _, line := range strings.Split(strings.TrimSpace(logs), "\n") {
+					fmt.Printf("  🧊 %s\n", strings.TrimPrefix(line, "frozen "))
+				}
+				return nil
+			}
+		case v1.PodFailed, v1.PodSucceeded:
+			return fmt.Errorf("helper pod %s ended: %s", current.Name, strings.TrimSpace(helperLogs(ctx, clientset, current)))
+		}
+		if err := holdChaos(ctx, 2*time.Second); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("helper pod %s did not freeze the processes within %s", created.Name, freezeStartTimeout)
+}
+
+// helperLogs returns the output of a helper pod, or "" if it cannot be read
+func helperLogs(ctx context.Context, clientset *kubernetes.Clientset, pod *v1.Pod) string {
+	logs, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{}).DoRaw(ctx)
+	if err != nil {
+		return ""
+	}
+	return string(logs)
+}
+
+// killSignals are the signals kill-process chaos can send
+var killSignals = []string{"HUP", "INT", "QUIT", "ABRT", "KILL", "USR1", "USR2", "SEGV", "PIPE", "ALRM", "TERM"}
+
+// signalNumbers are the Linux numbers of killSignals
+var signalNumbers = map[string]int{
+	"HUP": 1, "INT": 2, "QUIT": 3, "ABRT": 6, "KILL": 9, "USR1": 10,
+	"SEGV": 11, "USR2": 12, "PIPE": 13, "ALRM": 14, "TERM": 15,
+}
+
+// pauseSignals stop or continue processes; kill-process cannot revert them, so they belong to pod-freeze
+var pauseSignals = []string{"STOP", "CONT", "TSTP", "TTIN", "TTOU"}
+
+// killScript signals the processes of the container matching $PROCESS_MATCH,
+// at most $MAX_PROCESSES of them, and prints "PID command" for each. It never
+// signals itself or its helpers, and skips PID 1 unless $ALLOW_PID1 is 1. The
+// kernel drops signals PID 1 has no handler for, so those print "! 1 command".
+const killScript = `n=0
+for d in /proc/[0-9]*; do
+  p=${d#/proc/}
+  [ "$p" = "$$" ] && continue
+  [ "$p" = 1 ] && [ "$ALLOW_PID1" != 1 ] && continue
+  [ "$(sed 's/.*) //' "$d/stat" 2>/dev/null | cut -d' ' -f2)" = "$$" ] && continue
+  cmd=$(tr '\0' ' ' < "$d/cmdline" 2>/dev/null)
+  [ -n "$cmd" ] || continue
+  if [ -n "$PROCESS_MATCH" ] && ! echo "$cmd" | grep -Eq "$PROCESS_MATCH"; then continue; fi
+  if [ "$p" = 1 ]; then
+    caught=$(sed -n 's/^SigCgt:[[:space:]]*//p' /proc/1/status)
+    caught=${caught#????????}
+    if [ $(( (0x$caught >> (SIGNUM - 1)) & 1 )) != 1 ]; then echo "! $p $cmd"; continue; fi
+  fi
+  kill -s "$SIGNAL" "$p" 2>/dev/null || continue
+  echo "$p $cmd"
+  n=$((n+1))
+  [ "$n" -ge "$MAX_PROCESSES" ] && break
+done; true`
+
+// validateKillProcess checks the settings specific to kill-process chaos
+func validateKillProcess(config ChaosConfig) error {
+	if err := validateProcessMatch(config); err != nil {
+		return err
+	}
+	for _, signal := range pauseSignals {
+		if signal == config.Process.Signal {
+			return fmt.Errorf("signal %s pauses processes and kill-process never resumes them: use -chaos-type=%s instead", signal, ChaosTypePodFreeze)
+		}
+	}
+	known := false
+	for _, signal := range killSignals {
+		known = known || signal == config.Process.Signal
+	}
+	if !known {
+		return fmt.Errorf("invalid signal %q: must be one of %s", config.Process.Signal, strings.Join(killSignals, ", "))
+	}
+	if config.Process.Interval < 0 {
+		return fmt.Errorf("kill interval must not be negative")
+	}
+	if config.Process.MaxProcesses < 1 {
+		return fmt.Errorf("max processes must be at least 1, got %d", config.Process.MaxProcesses)
+	}
+	return nil
+}
+
+// parseSignal normalizes a signal name such as "sigterm" or "TERM" to "TERM"
+func parseSignal(name string) string {
+	return strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(name)), "SIG")
+}
+
+// shellQuote quotes a value for use as a single shell word
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// generateKillCommand creates the command that signals the selected processes in a container
+func generateKillCommand(process ProcessChaosConfig) string {
+	allowPID1 := 0
+	if process.AllowPID1 {
+		allowPID1 = 1
+	}
+	return fmt.Sprintf("PROCESS_MATCH=%s SIGNAL=%s SIGNUM=%d ALLOW_PID1=%d MAX_PROCESSES=%d; %s",
+		shellQuote(process.Match), process.Signal, signalNumbers[process.Signal], allowPID1, process.MaxProcesses, killScript)
+}
+
+// ApplyKillProcessChaos signals the selected processes in the main container of
+// the selected pods, once or every Process.Interval for the duration, and
+// reports the processes signalled in each pod
+func ApplyKillProcessChaos(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
+	process := chaosConfig.Process
+	fmt.Printf("💀 Applying KILL PROCESS chaos to namespace: %s (SIG%s to %s)\n", chaosConfig.namespaceScope(), process.Signal, describeProcessMatch(process.Match))
+
+	selectedPods, err := selectTargets(ctx, clientset, chaosConfig)
+	if err != nil {
+		return err
+	}
+
+	cmd := generateKillCommand(process)
+	if chaosConfig.DryRun {
+		fmt.Println("🔍 DRY RUN MODE - No process will be signalled")
+		fmt.Printf("📋 Would signal %s in %d pods:\n", describeProcessMatch(process.Match), len(selectedPods))
+		for i, pod := range selectedPods {
+			fmt.Printf("  %d. %s/%s\n", i+1, pod.Namespace, pod.Name)
+		}
+		fmt.Printf("📋 Command: %s\n", cmd)
+		return nil
+	}
+
+	for _, pod := range selectedPods {
+		// Start monitoring in background
+		go MonitorPodHealth(ctx, clientset, pod.Namespace, pod.Name, chaosConfig.Duration)
+	}
+
+	signalled := map[string][]string{}
+	end := time.Now().Add(chaosConfig.Duration)
+	for round := 1; ; round++ {
+		for i, pod := range selectedPods {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if len(pod.Spec.Containers) == 0 {
+				fmt.Printf("⚠️  Pod %s has no containers, skipping\n", pod.Name)
+				continue
+			}
+			containerName := pod.Spec.Containers[0].Name
+
+			fmt.Printf("💀 Signalling processes in pod %d/%d: %s (container: %s, round %d)\n", i+1, len(selectedPods), pod.Name, containerName, round)
+			output, err := execInPodOutput(ctx, config, clientset, pod.Namespace, pod.Name, containerName, cmd)
+			if err != nil {
+				fmt.Printf("❌ Failed to signal processes in pod %s: %v\n", pod.Name, err)
+				continue
+			}
+			var lines []string
+			for _, line := range splitLines(output) {
+				if dropped, ok := strings.CutPrefix(line, "! "); ok {
+					pid, command, _ := strings.Cut(dropped, " ")
+					fmt.Printf("  ⚠️  PID %s (%s) has no SIG%s handler, so the kernel drops it: not killed\n", pid, strings.TrimSpace(command), process.Signal)
+					continue
+				}
+				pid, command, _ := strings.Cut(line, " ")
+				fmt.Printf("  💀 SIG%s → PID %s (%s)\n", process.Signal, pid, strings.TrimSpace(command))
+				lines = append(lines, line)
+			}
+			if len(lines) == 0 {
+				fmt.Printf("⚠️  No matching processes signalled in pod %s\n", pod.Name)
+				continue
+			}
+			key := pod.Namespace + "/" + pod.Name
+			signalled[key] = append(signalled[key], lines...)
+		}
+
+		if process.Interval <= 0 || time.Now().Add(process.Interval).After(end) {
+			break
+		}
+		if err := holdChaos(ctx, process.Interval); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("📋 Signalled processes (SIG%s):\n", process.Signal)
+	for _, pod := range selectedPods {
+		key := pod.Namespace + "/" + pod.Name
+		fmt.Printf("  %s: %d\n", key, len(signalled[key]))
+		for _, line := range signalled[key] {
+			fmt.Printf("    %s\n", strings.TrimSpace(line))
+		}
+	}
+	if len(signalled) == 0 {
+		return fmt.Errorf("no process was signalled in any pod")
+	}
+	return nil
+}
+
+// splitLines splits command output into its non-empty lines
+func splitLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
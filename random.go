@@ -0,0 +1,50 @@
+package main
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// lockedSource makes a rand.Source safe for the parallel steps of a scenario
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// newRunRand returns the random source of a run. A seed of 0 picks one from
+// the clock; the seed used is returned so it can be recorded.
+func newRunRand(seed int64) (*rand.Rand, int64) {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(&lockedSource{src: rand.NewSource(seed).(rand.Source64)}), seed
+}
+
+// random returns the random source of the configuration, falling back to a
+// clock-seeded one when the configuration was built without it
+func (c ChaosConfig) random() *rand.Rand {
+	if c.Rand != nil {
+		return c.Rand
+	}
+	rng, _ := newRunRand(0)
+	return rng
+}
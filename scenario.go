@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario composes experiments into ordered stages
+type Scenario struct {
+	Name            string
+	Stages          []ScenarioStage
+	ContinueOnError bool // Keep running later stages after a step failed
+}
+
+// ScenarioStage is a group of steps. Stages run one after the other; the steps
+// of a stage run in order, or all at once when the stage is parallel.
+type ScenarioStage struct {
+	Name     string
+	Parallel bool
+	Steps    []ScenarioStep
+}
+
+// ScenarioStep waits, then runs an experiment. A step without an experiment only waits.
+type ScenarioStep struct {
+	Name       string
+	Wait       time.Duration // Delay before the step starts, counted from the start of the step (or stage, when parallel)
+	Experiment *Experiment
+}
+
+// ScenarioSpec is the file representation of a scenario
+type ScenarioSpec struct {
+	Name            string              `yaml:"name"`
+	ContinueOnError bool                `yaml:"continueOnError"`
+	Stages          []ScenarioStageSpec `yaml:"stages"`
+}
+
+// ScenarioStageSpec is the file representation of a scenario stage
+type ScenarioStageSpec struct {
+	Name     string             `yaml:"name"`
+	Parallel bool               `yaml:"parallel"`
+	Steps    []ScenarioStepSpec `yaml:"steps"`
+}
+
+// ScenarioStepSpec is the file representation of a scenario step: an experiment
+// with an optional wait before it starts
+type ScenarioStepSpec struct {
+	Wait           string `yaml:"wait"`
+	ExperimentSpec `yaml:",inline"`
+}
+
+// StepResult is the outcome of a single scenario step
+type StepResult struct {
+	Stage    string
+	Step     string
+	Type     ChaosType // Empty for wait-only steps
+	Started  time.Time
+	Duration time.Duration
+	Skipped  bool
+	Err      error
+}
+
+// LoadScenarioFile reads a scenario from a YAML or JSON file. Every step is
+// built like an experiment of an experiment file: it starts from base, gets
+// the file values applied, then override, and is validated before anything runs.
+func LoadScenarioFile(path string, base Experiment, override func(*Experiment)) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open scenario file: %v", err)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if len(document.Content) == 0 {
+		return nil, fmt.Errorf("%s: empty scenario file", path)
+	}
+	root := document.Content[0]
+
+	var spec ScenarioSpec
+	problems := decodeNode(root, &spec)
+	scenario := &Scenario{Name: spec.Name, ContinueOnError: spec.ContinueOnError}
+	if scenario.Name == "" {
+		scenario.Name = path
+	}
+	if len(problems) == 0 {
+		problems = buildScenarioStages(scenario, mappingValue(root, "stages"), spec, base, override)
+	}
+
+	if len(problems) > 0 {
+		var messages []string
+		for _, problem := range problems {
+			messages = append(messages, fmt.Sprintf("%s:%d: %s", path, problem.line, problem.msg))
+		}
+		return nil, errors.New(strings.Join(messages, "\n"))
+	}
+	return scenario, nil
+}
+
+// buildScenarioStages turns the stage specs into stages, validating every step
+func buildScenarioStages(scenario *Scenario, stagesNode *yaml.Node, spec ScenarioSpec, base Experiment, override func(*Experiment)) []lineError {
+	if stagesNode == nil || len(spec.Stages) == 0 {
+		return []lineError{{1, "scenario has no stages"}}
+	}
+
+	var problems []lineError
+	for i, stageSpec := range spec.Stages {
+		stageNode := stagesNode.Content[i]
+		stage := ScenarioStage{Name: stageSpec.Name, Parallel: stageSpec.Parallel}
+		if stage.Name == "" {
+			stage.Name = fmt.Sprintf("stage %d", i+1)
+		}
+
+		stepsNode := mappingValue(stageNode, "steps")
+		if stepsNode == nil || len(stageSpec.Steps) == 0 {
+			problems = append(problems, lineError{stageNode.Line, fmt.Sprintf("%s has no steps", stage.Name)})
+			continue
+		}
+		for j, stepSpec := range stageSpec.Steps {
+			step, stepProblems := buildScenarioStep(stepsNode.Content[j], stepSpec, base, override)
+			problems = append(problems, stepProblems...)
+			if step.Name == "" {
+				step.Name = fmt.Sprintf("step %d", j+1)
+			}
+			stage.Steps = append(stage.Steps, step)
+		}
+		scenario.Stages = append(scenario.Stages, stage)
+	}
+	return problems
+}
+
+// buildScenarioStep builds a single step. A step with a wait and no chaos type only waits.
+func buildScenarioStep(node *yaml.Node, spec ScenarioStepSpec, base Experiment, override func(*Experiment)) (ScenarioStep, []lineError) {
+	step := ScenarioStep{Name: spec.Name}
+	var problems []lineError
+	if spec.Wait != "" {
+		wait, err := time.ParseDuration(spec.Wait)
+		if err != nil || wait < 0 {
+			problems = append(problems, lineError{valueLine(node, "wait"), fmt.Sprintf("invalid wait %q", spec.Wait)})
+		}
+		step.Wait = wait
+		if spec.ChaosType == "" {
+			return step, problems
+		}
+	}
+
+	if spec.Schedule != "" {
+		problems = append(problems, lineError{valueLine(node, "schedule"), "scenario steps cannot have a schedule"})
+	}
+	experiment, experimentProblems := buildExperiment(node, spec.ExperimentSpec, base, override)
+	if experiment != nil && step.Name == "" {
+		step.Name = string(experiment.Chaos.Type)
+	}
+	step.Experiment = experiment
+	return step, append(problems, experimentProblems...)
+}
+
+// RunScenario runs the stages of the scenario in order and returns the result of every step
+func RunScenario(ctx context.Context, env ChaosEnv, scenario *Scenario) []StepResult {
+	fmt.Printf("🎬 Running scenario: %s (%d stages)\n", scenario.Name, len(scenario.Stages))
+
+	var results []StepResult
+	failed := false
+	for i, stage := range scenario.Stages {
+		if ctx.Err() != nil || (failed && !scenario.ContinueOnError) {
+			for _, step := range stage.Steps {
+				results = append(results, StepResult{Stage: stage.Name, Step: step.Name, Type: step.chaosType(), Skipped: true})
+			}
+			continue
+		}
+
+		mode := "sequential"
+		if stage.Parallel {
+			mode = "parallel"
+		}
+		fmt.Printf("🎬 Stage %d/%d: %s (%s, %d steps)\n", i+1, len(scenario.Stages), stage.Name, mode, len(stage.Steps))
+
+		stageResults := runScenarioStage(ctx, env, stage)
+		for _, result := range stageResults {
+			if result.Err != nil {
+				failed = true
+			}
+		}
+		results = append(results, stageResults...)
+	}
+	return results
+}
+
+// runScenarioStage runs the steps of a single stage
+func runScenarioStage(ctx context.Context, env ChaosEnv, stage ScenarioStage) []StepResult {
+	results := make([]StepResult, len(stage.Steps))
+	if !stage.Parallel {
+		for i, step := range stage.Steps {
+			results[i] = runScenarioStep(ctx, env, stage.Name, step)
+		}
+		return results
+	}
+
+	var wg sync.WaitGroup
+	for i, step := range stage.Steps {
+		wg.Add(1)
+		go func(i int, step ScenarioStep) {
+			defer wg.Done()
+			results[i] = runScenarioStep(ctx, env, stage.Name, step)
+		}(i, step)
+	}
+	wg.Wait()
+	return results
+}
+
+// runScenarioStep waits for the step's delay, then runs its experiment
+func runScenarioStep(ctx context.Context, env ChaosEnv, stageName string, step ScenarioStep) StepResult {
+	if step.Wait > 0 {
+		fmt.Printf("⏳ [%s] %s: waiting %s\n", stageName, step.Name, step.Wait)
+		if err := holdChaos(ctx, step.Wait); err != nil {
+			return StepResult{Stage: stageName, Step: step.Name, Type: step.chaosType(), Skipped: true}
+		}
+	}
+
+	result := StepResult{Stage: stageName, Step: step.Name, Type: step.chaosType(), Started: time.Now()}
+	if step.Experiment == nil {
+		result.Duration = step.Wait
+		return result
+	}
+
+	fmt.Printf("🚀 [%s] %s: starting %s\n", stageName, step.Name, step.Experiment.Chaos.Type)
+	injector, err := LookupInjector(step.Experiment.Chaos.Type)
+	if err == nil {
+		err = runGuarded(ctx, env, step.Name, injector, step.Experiment.Chaos, step.Experiment.SteadyState, step.Experiment.Abort)
+	}
+	result.Err = err
+	result.Duration = time.Since(result.Started)
+
+	if result.Err != nil {
+		fmt.Printf("❌ [%s] %s failed: %v\n", stageName, step.Name, result.Err)
+	} else {
+		fmt.Printf("✅ [%s] %s finished in %s\n", stageName, step.Name, result.Duration.Round(time.Second))
+	}
+	return result
+}
+
+// chaosType returns the chaos type run by the step, or "" for wait-only steps
+func (s ScenarioStep) chaosType() ChaosType {
+	if s.Experiment == nil {
+		return ""
+	}
+	return s.Experiment.Chaos.Type
+}
+
+// PrintScenarioReport prints the outcome of every step and reports whether all steps succeeded
+func PrintScenarioReport(scenario *Scenario, results []StepResult) bool {
+	fmt.Printf("\n📋 Scenario report: %s\n", scenario.Name)
+
+	succeeded := true
+	for _, result := range results {
+		chaosType := string(result.Type)
+		if chaosType == "" {
+			chaosType = "wait"
+		}
+
+		status := "✅ passed"
+		var abortErr *AbortError
+		switch {
+		case result.Skipped:
+			status = "⏭️  skipped"
+		case errors.As(result.Err, &abortErr):
+			status = fmt.Sprintf("🛑 aborted: %s", abortErr.Reason)
+			succeeded = false
+		case result.Err != nil:
+			status = fmt.Sprintf("❌ failed: %v", result.Err)
+			succeeded = false
+		}
+
+		duration := "-"
+		if !result.Skipped {
+			duration = result.Duration.Round(time.Second).String()
+		}
+		fmt.Printf("  %-16s %-24s %-22s %8s  %s\n", result.Stage, result.Step, chaosType, duration, status)
+	}
+	return succeeded
+}
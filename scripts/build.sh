@@ -15,17 +15,17 @@ mkdir -p $BUILD_DIR
 
 # Build for different platforms
 echo "Building for Windows..."
-GOOS=windows GOARCH=amd64 go build -o $BUILD_DIR/chaos-monkey-windows-amd64.exe main.go test_pods.go chaos_types.go version.go
-GOOS=windows GOARCH=386 go build -o $BUILD_DIR/chaos-monkey-windows-386.exe main.go test_pods.go chaos_types.go version.go
+GOOS=windows GOARCH=amd64 go build -o $BUILD_DIR/chaos-monkey-windows-amd64.exe .
+GOOS=windows GOARCH=386 go build -o $BUILD_DIR/chaos-monkey-windows-386.exe .
 
 echo "Building for Linux..."
-GOOS=linux GOARCH=amd64 go build -o $BUILD_DIR/chaos-monkey-linux-amd64 main.go test_pods.go chaos_types.go version.go
-GOOS=linux GOARCH=386 go build -o $BUILD_DIR/chaos-monkey-linux-386 main.go test_pods.go chaos_types.go version.go
-GOOS=linux GOARCH=arm64 go build -o $BUILD_DIR/chaos-monkey-linux-arm64 main.go test_pods.go chaos_types.go version.go
+GOOS=linux GOARCH=amd64 go build -o $BUILD_DIR/chaos-monkey-linux-amd64 .
+GOOS=linux GOARCH=386 go build -o $BUILD_DIR/chaos-monkey-linux-386 .
+GOOS=linux GOARCH=arm64 go build -o $BUILD_DIR/chaos-monkey-linux-arm64 .
 
 echo "Building for macOS..."
-GOOS=darwin GOARCH=amd64 go build -o $BUILD_DIR/chaos-monkey-darwin-amd64 main.go test_pods.go chaos_types.go version.go
-GOOS=darwin GOARCH=arm64 go build -o $BUILD_DIR/chaos-monkey-darwin-arm64 main.go test_pods.go chaos_types.go version.go
+GOOS=darwin GOARCH=amd64 go build -o $BUILD_DIR/chaos-monkey-darwin-amd64 .
+GOOS=darwin GOARCH=arm64 go build -o $BUILD_DIR/chaos-monkey-darwin-arm64 .
 
 # Create checksums
 echo "Creating checksums..."
@@ -22,35 +22,35 @@ Write-Host "Building binaries for all platforms..." -ForegroundColor Cyan
 Write-Host "Building for Windows..." -ForegroundColor Green
 $env:GOOS = "windows"
 $env:GOARCH = "amd64"
-go build -o "$BUILD_DIR\chaos-monkey-windows-amd64.exe" ../main.go ../test_pods.go ../chaos_types.go ../version.go
+go build -o "$BUILD_DIR\chaos-monkey-windows-amd64.exe" ..
 
 $env:GOOS = "windows"
 $env:GOARCH = "386"
-go build -o "$BUILD_DIR\chaos-monkey-windows-386.exe" ../main.go ../test_pods.go ../chaos_types.go ../version.go
+go build -o "$BUILD_DIR\chaos-monkey-windows-386.exe" ..
 
 # Build for Linux
 Write-Host "Building for Linux..." -ForegroundColor Green
 $env:GOOS = "linux"
 $env:GOARCH = "amd64"
-go build -o "$BUILD_DIR\chaos-monkey-linux-amd64" ../main.go ../test_pods.go ../chaos_types.go ../version.go
+go build -o "$BUILD_DIR\chaos-monkey-linux-amd64" ..
 
 $env:GOOS = "linux"
 $env:GOARCH = "386"
-go build -o "$BUILD_DIR\chaos-monkey-linux-386" ../main.go ../test_pods.go ../chaos_types.go ../version.go
+go build -o "$BUILD_DIR\chaos-monkey-linux-386" ..
 
 $env:GOOS = "linux"
 $env:GOARCH = "arm64"
-go build -o "$BUILD_DIR\chaos-monkey-linux-arm64" ../main.go ../test_pods.go ../chaos_types.go ../version.go
+go build -o "$BUILD_DIR\chaos-monkey-linux-arm64" ..
 
 # Build for macOS
 Write-Host "Building for macOS..." -ForegroundColor Green
 $env:GOOS = "darwin"
 $env:GOARCH = "amd64"
-go build -o "$BUILD_DIR\chaos-monkey-darwin-amd64" ../main.go ../test_pods.go ../chaos_types.go ../version.go
+go build -o "$BUILD_DIR\chaos-monkey-darwin-amd64" ..
 
 $env:GOOS = "darwin"
 $env:GOARCH = "arm64"
-go build -o "$BUILD_DIR\chaos-monkey-darwin-arm64" ../main.go ../test_pods.go ../chaos_types.go ../version.go
+go build -o "$BUILD_DIR\chaos-monkey-darwin-arm64" ..
 
 # Create checksums
 Write-Host "Creating checksums..." -ForegroundColor Cyan
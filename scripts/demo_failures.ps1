@@ -52,12 +52,12 @@ Write-Host "This should cause Out of Memory kills..." -ForegroundColor Gray
 
 # Create test pods
 Write-Host "Creating test pods..." -ForegroundColor Cyan
-go run main.go test_pods.go chaos_types.go -create -count=3 -namespace=default
+go run . -create -count=3 -namespace=default
 
 # Apply extreme memory stress
 Write-Host "Applying extreme memory stress (intensity 10)..." -ForegroundColor Cyan
 Start-Job -ScriptBlock {
-    go run main.go test_pods.go chaos_types.go -chaos-type=in-pod-memory-stress -intensity=10 -duration=60s -namespace=default
+    go run . -chaos-type=in-pod-memory-stress -intensity=10 -duration=60s -namespace=default
 }
 
 # Monitor for 70 seconds
@@ -73,12 +73,12 @@ Write-Host "This should kill random processes and cause crashes..." -ForegroundC
 
 # Create fresh test pods
 Write-Host "Creating fresh test pods..." -ForegroundColor Cyan
-go run main.go test_pods.go chaos_types.go -create -count=2 -namespace=default
+go run . -create -count=2 -namespace=default
 
 # Apply process killing chaos
 Write-Host "Applying process killing chaos..." -ForegroundColor Cyan
 Start-Job -ScriptBlock {
-    go run main.go test_pods.go chaos_types.go -chaos-type=kill-process -intensity=5 -duration=30s -namespace=default
+    go run . -chaos-type=kill-process -intensity=5 -duration=30s -namespace=default
 }
 
 # Monitor for 40 seconds
@@ -94,12 +94,12 @@ Write-Host "This is the most aggressive test - may cause crashes..." -Foreground
 
 # Create fresh test pods
 Write-Host "Creating fresh test pods..." -ForegroundColor Cyan
-go run main.go test_pods.go chaos_types.go -create -count=1 -namespace=default
+go run . -create -count=1 -namespace=default
 
 # Apply memory corruption chaos
 Write-Host "Applying memory corruption chaos..." -ForegroundColor Cyan
 Start-Job -ScriptBlock {
-    go run main.go test_pods.go chaos_types.go -chaos-type=corrupt-memory -intensity=3 -duration=20s -namespace=default
+    go run . -chaos-type=corrupt-memory -intensity=3 -duration=20s -namespace=default
 }
 
 # Monitor for 30 seconds
@@ -115,12 +115,12 @@ Write-Host "Combining CPU and memory stress for maximum impact..." -ForegroundCo
 
 # Create fresh test pods
 Write-Host "Creating fresh test pods..." -ForegroundColor Cyan
-go run main.go test_pods.go chaos_types.go -create -count=2 -namespace=default
+go run . -create -count=2 -namespace=default
 
 # Apply mixed stress chaos
 Write-Host "Applying mixed stress chaos..." -ForegroundColor Cyan
 Start-Job -ScriptBlock {
-    go run main.go test_pods.go chaos_types.go -chaos-type=in-pod-mixed-stress -intensity=8 -duration=45s -namespace=default
+    go run . -chaos-type=in-pod-mixed-stress -intensity=8 -duration=45s -namespace=default
 }
 
 # Monitor for 50 seconds
@@ -144,7 +144,7 @@ Write-Host "3. Memory corruption - Application crashes or becomes unstable" -For
 Write-Host "4. Mixed resource stress - Combined CPU and memory pressure" -ForegroundColor Gray
 
 Write-Host "`n🧹 Cleaning up test pods..." -ForegroundColor Cyan
-go run main.go test_pods.go chaos_types.go -cleanup -namespace=default
+go run . -cleanup -namespace=default
 
 Write-Host "`n✅ Failure demonstration completed!" -ForegroundColor Green
 
@@ -52,11 +52,11 @@ echo "This should cause Out of Memory kills..."
 
 # Create test pods
 echo -e "${CYAN}Creating test pods...${NC}"
-go run main.go test_pods.go chaos_types.go -create -count=3 -namespace=default
+go run . -create -count=3 -namespace=default
 
 # Apply extreme memory stress
 echo -e "${CYAN}Applying extreme memory stress (intensity 10)...${NC}"
-go run main.go test_pods.go chaos_types.go -chaos-type=in-pod-memory-stress -intensity=10 -duration=60s -namespace=default &
+go run . -chaos-type=in-pod-memory-stress -intensity=10 -duration=60s -namespace=default &
 CHAOS_PID=$!
 
 # Monitor for 70 seconds
@@ -72,11 +72,11 @@ echo "This should kill random processes and cause crashes..."
 
 # Create fresh test pods
 echo -e "${CYAN}Creating fresh test pods...${NC}"
-go run main.go test_pods.go chaos_types.go -create -count=2 -namespace=default
+go run . -create -count=2 -namespace=default
 
 # Apply process killing chaos
 echo -e "${CYAN}Applying process killing chaos...${NC}"
-go run main.go test_pods.go chaos_types.go -chaos-type=kill-process -intensity=5 -duration=30s -namespace=default &
+go run . -chaos-type=kill-process -intensity=5 -duration=30s -namespace=default &
 CHAOS_PID=$!
 
 # Monitor for 40 seconds
@@ -92,11 +92,11 @@ echo "This is the most aggressive test - may cause crashes..."
 
 # Create fresh test pods
 echo -e "${CYAN}Creating fresh test pods...${NC}"
-go run main.go test_pods.go chaos_types.go -create -count=1 -namespace=default
+go run . -create -count=1 -namespace=default
 
 # Apply memory corruption chaos
 echo -e "${CYAN}Applying memory corruption chaos...${NC}"
-go run main.go test_pods.go chaos_types.go -chaos-type=corrupt-memory -intensity=3 -duration=20s -namespace=default &
+go run . -chaos-type=corrupt-memory -intensity=3 -duration=20s -namespace=default &
 CHAOS_PID=$!
 
 # Monitor for 30 seconds
@@ -112,11 +112,11 @@ echo "Combining CPU and memory stress for maximum impact..."
 
 # Create fresh test pods
 echo -e "${CYAN}Creating fresh test pods...${NC}"
-go run main.go test_pods.go chaos_types.go -create -count=2 -namespace=default
+go run . -create -count=2 -namespace=default
 
 # Apply mixed stress chaos
 echo -e "${CYAN}Applying mixed stress chaos...${NC}"
-go run main.go test_pods.go chaos_types.go -chaos-type=in-pod-mixed-stress -intensity=8 -duration=45s -namespace=default &
+go run . -chaos-type=in-pod-mixed-stress -intensity=8 -duration=45s -namespace=default &
 CHAOS_PID=$!
 
 # Monitor for 50 seconds
@@ -140,7 +140,7 @@ echo "3. ${RED}Memory corruption${NC} - Application crashes or becomes unstable"
 echo "4. ${YELLOW}Mixed resource stress${NC} - Combined CPU and memory pressure"
 
 echo -e "\n${CYAN}🧹 Cleaning up test pods...${NC}"
-go run main.go test_pods.go chaos_types.go -cleanup -namespace=default
+go run . -cleanup -namespace=default
 
 echo -e "\n${GREEN}✅ Failure demonstration completed!${NC}"
 
@@ -85,7 +85,7 @@ Write-Host "This should cause CPU pressure on nginx containers..." -ForegroundCo
 # Apply CPU stress to nginx pods
 Write-Host "Applying CPU stress to nginx pods..." -ForegroundColor Cyan
 Start-Job -ScriptBlock {
-    go run main.go test_pods.go chaos_types.go -chaos-type=in-pod-cpu-stress -intensity=7 -duration=60s -labels="app=nginx"
+    go run . -chaos-type=in-pod-cpu-stress -intensity=7 -duration=60s -labels="app=nginx"
 }
 
 # Monitor for 70 seconds
@@ -102,7 +102,7 @@ Write-Host "This should cause memory pressure on nginx containers..." -Foregroun
 # Apply memory stress to nginx pods
 Write-Host "Applying memory stress to nginx pods..." -ForegroundColor Cyan
 Start-Job -ScriptBlock {
-    go run main.go test_pods.go chaos_types.go -chaos-type=in-pod-memory-stress -intensity=6 -duration=45s -labels="app=nginx"
+    go run . -chaos-type=in-pod-memory-stress -intensity=6 -duration=45s -labels="app=nginx"
 }
 
 # Monitor for 50 seconds
@@ -119,7 +119,7 @@ Write-Host "This should kill random processes in nginx containers..." -Foregroun
 # Apply process killing to nginx pods
 Write-Host "Applying process killing to nginx pods..." -ForegroundColor Cyan
 Start-Job -ScriptBlock {
-    go run main.go test_pods.go chaos_types.go -chaos-type=kill-process -intensity=3 -duration=30s -labels="app=nginx"
+    go run . -chaos-type=kill-process -intensity=3 -duration=30s -labels="app=nginx"
 }
 
 # Monitor for 40 seconds
@@ -136,7 +136,7 @@ Write-Host "Combining CPU and memory stress for maximum impact..." -ForegroundCo
 # Apply mixed stress to nginx pods
 Write-Host "Applying mixed stress to nginx pods..." -ForegroundColor Cyan
 Start-Job -ScriptBlock {
-    go run main.go test_pods.go chaos_types.go -chaos-type=in-pod-mixed-stress -intensity=5 -duration=40s -labels="app=nginx"
+    go run . -chaos-type=in-pod-mixed-stress -intensity=5 -duration=40s -labels="app=nginx"
 }
 
 # Monitor for 50 seconds
@@ -153,7 +153,7 @@ Write-Host "Running chaos every 30 seconds for 2 minutes..." -ForegroundColor Gr
 # Start cron-based chaos
 Write-Host "Starting cron-based chaos..." -ForegroundColor Cyan
 $cronJob = Start-Job -ScriptBlock {
-    go run main.go test_pods.go chaos_types.go -cron="*/30 * * * * *" -chaos-type=in-pod-cpu-stress -intensity=4 -labels="app=nginx"
+    go run . -cron="*/30 * * * * *" -chaos-type=in-pod-cpu-stress -intensity=4 -labels="app=nginx"
 }
 
 # Monitor for 2 minutes
@@ -88,7 +88,7 @@ echo "This should cause CPU pressure on nginx containers..."
 
 # Apply CPU stress to nginx pods
 echo -e "${CYAN}Applying CPU stress to nginx pods...${NC}"
-go run main.go test_pods.go chaos_types.go -chaos-type=in-pod-cpu-stress -intensity=7 -duration=60s -labels="app=nginx" &
+go run . -chaos-type=in-pod-cpu-stress -intensity=7 -duration=60s -labels="app=nginx" &
 CHAOS_PID=$!
 
 # Monitor for 70 seconds
@@ -104,7 +104,7 @@ echo "This should cause memory pressure on nginx containers..."
 
 # Apply memory stress to nginx pods
 echo -e "${CYAN}Applying memory stress to nginx pods...${NC}"
-go run main.go test_pods.go chaos_types.go -chaos-type=in-pod-memory-stress -intensity=6 -duration=45s -labels="app=nginx" &
+go run . -chaos-type=in-pod-memory-stress -intensity=6 -duration=45s -labels="app=nginx" &
 CHAOS_PID=$!
 
 # Monitor for 50 seconds
@@ -120,7 +120,7 @@ echo "This should kill random processes in nginx containers..."
 
 # Apply process killing to nginx pods
 echo -e "${CYAN}Applying process killing to nginx pods...${NC}"
-go run main.go test_pods.go chaos_types.go -chaos-type=kill-process -intensity=3 -duration=30s -labels="app=nginx" &
+go run . -chaos-type=kill-process -intensity=3 -duration=30s -labels="app=nginx" &
 CHAOS_PID=$!
 
 # Monitor for 40 seconds
@@ -136,7 +136,7 @@ echo "Combining CPU and memory stress for maximum impact..."
 
 # Apply mixed stress to nginx pods
 echo -e "${CYAN}Applying mixed stress to nginx pods...${NC}"
-go run main.go test_pods.go chaos_types.go -chaos-type=in-pod-mixed-stress -intensity=5 -duration=40s -labels="app=nginx" &
+go run . -chaos-type=in-pod-mixed-stress -intensity=5 -duration=40s -labels="app=nginx" &
 CHAOS_PID=$!
 
 # Monitor for 50 seconds
@@ -152,7 +152,7 @@ echo "Running chaos every 30 seconds for 2 minutes..."
 
 # Start cron-based chaos
 echo -e "${CYAN}Starting cron-based chaos...${NC}"
-go run main.go test_pods.go chaos_types.go -cron="*/30 * * * * *" -chaos-type=in-pod-cpu-stress -intensity=4 -labels="app=nginx" &
+go run . -cron="*/30 * * * * *" -chaos-type=in-pod-cpu-stress -intensity=4 -labels="app=nginx" &
 CRON_PID=$!
 
 # Monitor for 2 minutes
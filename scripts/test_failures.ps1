@@ -60,12 +60,12 @@ Write-Host "This should cause Out of Memory kills..." -ForegroundColor Gray
 
 # Create test pods
 Write-Host "Creating test pods..." -ForegroundColor Cyan
-go run main.go test_pods.go chaos_types.go -create -count=3 -namespace=default
+go run . -create -count=3 -namespace=default
 
 # Apply extreme memory stress
 Write-Host "Applying extreme memory stress..." -ForegroundColor Cyan
 Start-Job -ScriptBlock {
-    go run main.go test_pods.go chaos_types.go -chaos-type=in-pod-memory-stress -intensity=10 -duration=60s -namespace=default
+    go run . -chaos-type=in-pod-memory-stress -intensity=10 -duration=60s -namespace=default
 }
 
 # Monitor for failures
@@ -81,12 +81,12 @@ Write-Host "This should kill random processes and cause crashes..." -ForegroundC
 
 # Create fresh test pods
 Write-Host "Creating fresh test pods..." -ForegroundColor Cyan
-go run main.go test_pods.go chaos_types.go -create -count=2 -namespace=default
+go run . -create -count=2 -namespace=default
 
 # Apply process killing chaos
 Write-Host "Applying process killing chaos..." -ForegroundColor Cyan
 Start-Job -ScriptBlock {
-    go run main.go test_pods.go chaos_types.go -chaos-type=kill-process -intensity=5 -duration=30s -namespace=default
+    go run . -chaos-type=kill-process -intensity=5 -duration=30s -namespace=default
 }
 
 # Monitor for failures
@@ -102,12 +102,12 @@ Write-Host "This is the most aggressive test - may cause crashes..." -Foreground
 
 # Create fresh test pods
 Write-Host "Creating fresh test pods..." -ForegroundColor Cyan
-go run main.go test_pods.go chaos_types.go -create -count=1 -namespace=default
+go run . -create -count=1 -namespace=default
 
 # Apply memory corruption chaos
 Write-Host "Applying memory corruption chaos..." -ForegroundColor Cyan
 Start-Job -ScriptBlock {
-    go run main.go test_pods.go chaos_types.go -chaos-type=corrupt-memory -intensity=3 -duration=20s -namespace=default
+    go run . -chaos-type=corrupt-memory -intensity=3 -duration=20s -namespace=default
 }
 
 # Monitor for failures
@@ -123,12 +123,12 @@ Write-Host "This should cause network timeouts..." -ForegroundColor Gray
 
 # Create fresh test pods
 Write-Host "Creating fresh test pods..." -ForegroundColor Cyan
-go run main.go test_pods.go chaos_types.go -create -count=2 -namespace=default
+go run . -create -count=2 -namespace=default
 
 # Apply network chaos
 Write-Host "Applying network latency chaos..." -ForegroundColor Cyan
 Start-Job -ScriptBlock {
-    go run main.go test_pods.go chaos_types.go -chaos-type=network-latency -intensity=7 -duration=45s -namespace=default
+    go run . -chaos-type=network-latency -intensity=7 -duration=45s -namespace=default
 }
 
 # Monitor for failures
@@ -188,6 +188,6 @@ Write-Host "3. Memory corruption - Application crashes or becomes unstable" -For
 Write-Host "4. Network timeouts - Services become unresponsive" -ForegroundColor Gray
 
 Write-Host "`nCleaning up test pods..." -ForegroundColor Cyan
-go run main.go test_pods.go chaos_types.go -cleanup -namespace=default
+go run . -cleanup -namespace=default
 
 Write-Host "`nFailure testing completed!" -ForegroundColor Green 
\ No newline at end of file
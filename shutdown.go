@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// activeFault is a fault that has been injected and not reverted yet
+type activeFault struct {
+	name     string
+	injector ChaosInjector
+	config   ChaosConfig
+}
+
+// faultTracker keeps the faults that are in flight, so shutdown can revert them
+type faultTracker struct {
+	mu     sync.Mutex
+	faults map[*activeFault]bool
+}
+
+// activeFaults tracks every fault injected by this process
+var activeFaults = &faultTracker{faults: map[*activeFault]bool{}}
+
+// add tracks a fault until the returned function is called
+func (t *faultTracker) add(name string, injector ChaosInjector, config ChaosConfig) func() {
+	fault := &activeFault{name: name, injector: injector, config: config}
+	t.mu.Lock()
+	t.faults[fault] = true
+	t.mu.Unlock()
+
+	return func() {
+		t.mu.Lock()
+		delete(t.faults, fault)
+		t.mu.Unlock()
+	}
+}
+
+// list returns the tracked faults
+func (t *faultTracker) list() []*activeFault {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	faults := make([]*activeFault, 0, len(t.faults))
+	for fault := range t.faults {
+		faults = append(faults, fault)
+	}
+	return faults
+}
+
+// signalContext returns a context that is cancelled on SIGINT or SIGTERM. After
+// the first signal the default handling is restored, so a second one exits at once.
+func signalContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		signal.Stop(signals)
+		fmt.Printf("\n🛑 Received %s, stopping chaos (press Ctrl+C again to force quit)...\n", sig)
+		cancel()
+	}()
+	return ctx
+}
+
+// revertActiveFaults reverts every fault that is still active, giving up after
+// the grace period. It reports whether everything was reverted.
+func revertActiveFaults(env ChaosEnv, grace time.Duration) bool {
+	faults := activeFaults.list()
+	if len(faults) == 0 {
+		return true
+	}
+
+	fmt.Printf("🔧 Reverting %d active faults (grace period: %s)...\n", len(faults), grace)
+	ctx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	results := make([]error, len(faults))
+	for i, fault := range faults {
+		wg.Add(1)
+		go func(i int, fault *activeFault) {
+			defer wg.Done()
+			results[i] = fault.injector.Revert(ctx, env, fault.config)
+		}(i, fault)
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-ctx.Done():
+		fmt.Printf("⚠️  Grace period of %s expired before all faults were reverted\n", grace)
+		fmt.Println("💡 Tip: Run with -cleanup to remove what is left")
+		return false
+	}
+
+	reverted := true
+	for i, fault := range faults {
+		if results[i] != nil {
+			fmt.Printf("❌ Failed to revert %s: %v\n", fault.name, results[i])
+			reverted = false
+			continue
+		}
+		fmt.Printf("✅ Reverted %s\n", fault.name)
+	}
+	return reverted
+}
+
+// shutdown reverts the active faults once the root context was cancelled, then exits
+func shutdown(env ChaosEnv, grace time.Duration) {
+	if !revertActiveFaults(env, grace) {
+		os.Exit(1)
+	}
+	fmt.Println("👋 Chaos stopped, all faults reverted")
+	os.Exit(130)
+}
@@ -0,0 +1,43 @@
+package targeting
+
+import (
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Annotations service owners use to control chaos on their own pods and
+// namespaces. Pods get them from the pod template of their workload.
+const (
+	// ExcludeAnnotation set to "true" on a pod, namespace or node protects it from every chaos type
+	ExcludeAnnotation = "kubechaos.io/exclude"
+	// EnabledAnnotation set to "true" opts a pod in; in strict mode only such pods are targeted
+	EnabledAnnotation = "kubechaos.io/enabled"
+	// AllowedChaosAnnotation lists the chaos types a pod accepts, e.g. "pod-delete,network-latency"
+	AllowedChaosAnnotation = "kubechaos.io/allowed-chaos"
+)
+
+// OptedOut reports whether the annotations exclude the object from chaos
+func OptedOut(annotations map[string]string) bool {
+	return annotations[ExcludeAnnotation] == "true"
+}
+
+// optedIn reports whether the annotations enable chaos on the object
+func optedIn(annotations map[string]string) bool {
+	return annotations[EnabledAnnotation] == "true"
+}
+
+// chaosAllowed reports whether the pod accepts the chaos type. Pods without
+// AllowedChaosAnnotation accept every chaos type.
+func chaosAllowed(pod v1.Pod, chaosType string) bool {
+	allowed, ok := pod.Annotations[AllowedChaosAnnotation]
+	if !ok {
+		return true
+	}
+	for _, t := range strings.Split(allowed, ",") {
+		if strings.TrimSpace(t) == chaosType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package targeting
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SystemNamespaces are never targeted, whatever the namespace selection says
+var SystemNamespaces = []string{"kube-system", "kube-public", "kube-node-lease"}
+
+// NamespaceCriteria describes which namespaces chaos may target
+type NamespaceCriteria struct {
+	// Names lists namespace names or glob patterns such as "team-*"; "*" selects every namespace
+	Names []string
+	// Selector matches namespaces by their labels, e.g. "team=payments"
+	Selector labels.Selector
+	// Deny lists additional names or glob patterns that are never targeted
+	Deny []string
+}
+
+// IsZero reports whether no namespace selection was configured
+func (c NamespaceCriteria) IsZero() bool {
+	return len(c.Names) == 0 && c.Selector == nil
+}
+
+// String describes the selection for log output
+func (c NamespaceCriteria) String() string {
+	var parts []string
+	if len(c.Names) > 0 {
+		parts = append(parts, strings.Join(c.Names, ","))
+	}
+	if c.Selector != nil {
+		parts = append(parts, fmt.Sprintf("selector %q", c.Selector.String()))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// ResolveNamespaces returns the sorted namespaces matching the criteria, and the
+// matching namespaces that were dropped because they are denied. Denied namespaces
+// (SystemNamespaces, criteria.Deny and namespaces annotated with
+// ExcludeAnnotation) are never returned.
+func ResolveNamespaces(ctx context.Context, client kubernetes.Interface, criteria NamespaceCriteria) ([]string, []string, error) {
+	deny := append(append([]string{}, SystemNamespaces...), criteria.Deny...)
+	for _, pattern := range append(append([]string{}, criteria.Names...), deny...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, nil, fmt.Errorf("invalid namespace pattern %q: %v", pattern, err)
+		}
+	}
+
+	var matched []string
+	excluded := map[string]bool{}
+	if criteria.Selector == nil && !hasPattern(criteria.Names) {
+		// Plain names do not need cluster-wide list permissions. Namespaces
+		// that cannot be read are not checked for ExcludeAnnotation.
+		matched = criteria.Names
+		for _, name := range matched {
+			namespace, err := client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+			if err == nil && OptedOut(namespace.Annotations) {
+				excluded[name] = true
+			}
+		}
+	} else {
+		listOptions := metav1.ListOptions{}
+		if criteria.Selector != nil {
+			listOptions.LabelSelector = criteria.Selector.String()
+		}
+		namespaces, err := client.CoreV1().Namespaces().List(ctx, listOptions)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list namespaces: %v", err)
+		}
+		for _, namespace := range namespaces.Items {
+			if len(criteria.Names) == 0 || matchesAny(namespace.Name, criteria.Names) {
+				matched = append(matched, namespace.Name)
+			}
+			if OptedOut(namespace.Annotations) {
+				excluded[namespace.Name] = true
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	var resolved, denied []string
+	for _, name := range matched {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if matchesAny(name, deny) || excluded[name] {
+			denied = append(denied, name)
+			continue
+		}
+		resolved = append(resolved, name)
+	}
+	sort.Strings(resolved)
+	sort.Strings(denied)
+
+	if len(resolved) == 0 {
+		return nil, denied, fmt.Errorf("no allowed namespaces match %s", criteria)
+	}
+	return resolved, denied, nil
+}
+
+// hasPattern reports whether any of the names is a glob pattern
+func hasPattern(names []string) bool {
+	for _, name := range names {
+		if strings.ContainsAny(name, "*?[") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny reports whether the name matches any of the names or glob patterns
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
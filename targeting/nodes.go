@@ -0,0 +1,113 @@
+package targeting
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NodeCriteria describes which nodes are eligible as chaos targets
+type NodeCriteria struct {
+	// LabelSelector is passed to the API as is; nil selects every node
+	LabelSelector labels.Selector
+	// Zones only accepts nodes in these topology.kubernetes.io/zone zones; empty accepts any
+	Zones []string
+	// ExcludeAnnotations skips nodes carrying any of these annotation keys,
+	// in addition to ExcludeAnnotation set to "true"
+	ExcludeAnnotations []string
+}
+
+// String describes the criteria for log output
+func (c NodeCriteria) String() string {
+	var parts []string
+	if c.LabelSelector != nil && !c.LabelSelector.Empty() {
+		parts = append(parts, fmt.Sprintf("labels %q", c.LabelSelector.String()))
+	}
+	if len(c.Zones) > 0 {
+		parts = append(parts, "zones "+strings.Join(c.Zones, ","))
+	}
+	if len(parts) == 0 {
+		return "all nodes"
+	}
+	return strings.Join(parts, " + ")
+}
+
+// NodeCandidateSet holds the nodes that passed the selection criteria
+type NodeCandidateSet struct {
+	Nodes []v1.Node
+	// Listed is the number of nodes returned by the API before filtering
+	Listed int
+	// Excluded counts the filtered nodes by reason
+	Excluded map[string]int
+}
+
+// FindNodes lists the nodes matching the criteria. Nodes that are cordoned or
+// not Ready are skipped, so a fault never hits a node that is already down.
+func FindNodes(ctx context.Context, client kubernetes.Interface, criteria NodeCriteria) (*NodeCandidateSet, error) {
+	listOptions := metav1.ListOptions{}
+	if criteria.LabelSelector != nil {
+		listOptions.LabelSelector = criteria.LabelSelector.String()
+	}
+	nodes, err := client.CoreV1().Nodes().List(ctx, listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	set := &NodeCandidateSet{Listed: len(nodes.Items), Excluded: map[string]int{}}
+	for _, node := range nodes.Items {
+		reason := nodeExclusionReason(node, criteria)
+		if reason != "" {
+			set.Excluded[reason]++
+			continue
+		}
+		set.Nodes = append(set.Nodes, node)
+	}
+	return set, nil
+}
+
+// nodeExclusionReason returns why the node is not a candidate, or "" if it is one
+func nodeExclusionReason(node v1.Node, criteria NodeCriteria) string {
+	if OptedOut(node.Annotations) {
+		return "opted out"
+	}
+	for _, key := range criteria.ExcludeAnnotations {
+		if _, ok := node.Annotations[key]; ok {
+			return "under chaos"
+		}
+	}
+	if len(criteria.Zones) > 0 && !contains(criteria.Zones, node.Labels[v1.LabelTopologyZone]) {
+		return "other zone"
+	}
+	if node.Spec.Unschedulable {
+		return "cordoned"
+	}
+	if !IsNodeReady(node) {
+		return "not ready"
+	}
+	return ""
+}
+
+// IsNodeReady reports whether the node's Ready condition is true
+func IsNodeReady(node v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// contains reports whether the value is one of the items
+func contains(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package targeting
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// OwnerKinds lists the top-level owner kinds pods are resolved to
+var OwnerKinds = []string{"Deployment", "StatefulSet", "DaemonSet", "Job", "ReplicaSet"}
+
+// Owner is the top-level workload controlling a pod. Pods without a controller
+// have the zero Owner.
+type Owner struct {
+	Kind string
+	Name string
+}
+
+func (o Owner) String() string {
+	if o.Kind == "" {
+		return "no owner"
+	}
+	return o.Kind + "/" + o.Name
+}
+
+// ResolveOwners resolves the top-level owner of every candidate. A ReplicaSet
+// controlled by a Deployment resolves to the Deployment; StatefulSets,
+// DaemonSets and Jobs own their pods directly.
+func (s *CandidateSet) ResolveOwners(ctx context.Context, client kubernetes.Interface) error {
+	s.Owners = make(map[types.UID]Owner, len(s.Pods))
+	replicaSets := map[string]Owner{}
+	for _, pod := range s.Pods {
+		owner, err := resolveOwner(ctx, client, pod, replicaSets)
+		if err != nil {
+			return err
+		}
+		s.Owners[pod.UID] = owner
+	}
+	return nil
+}
+
+// resolveOwner follows the controller references of a pod up to its top-level owner
+func resolveOwner(ctx context.Context, client kubernetes.Interface, pod v1.Pod, replicaSets map[string]Owner) (Owner, error) {
+	ref := metav1.GetControllerOf(&pod)
+	if ref == nil {
+		return Owner{}, nil
+	}
+	owner := Owner{Kind: ref.Kind, Name: ref.Name}
+	if ref.Kind != "ReplicaSet" {
+		return owner, nil
+	}
+
+	key := pod.Namespace + "/" + ref.Name
+	if cached, ok := replicaSets[key]; ok {
+		return cached, nil
+	}
+	rs, err := client.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		// The ReplicaSet is being deleted, the pod will follow
+	case err != nil:
+		return Owner{}, fmt.Errorf("failed to get owner of pod %s: %v", pod.Name, err)
+	default:
+		if deployment := metav1.GetControllerOf(rs); deployment != nil && deployment.Kind == "Deployment" {
+			owner = Owner{Kind: deployment.Kind, Name: deployment.Name}
+		}
+	}
+	replicaSets[key] = owner
+	return owner, nil
+}
+
+// OwnerKey groups the candidates by owner, e.g. "shop/Deployment/web". Every
+// pod without an owner is a group of its own.
+func (s *CandidateSet) OwnerKey(pod v1.Pod) string {
+	owner, ok := s.Owners[pod.UID]
+	if !ok || owner.Kind == "" {
+		return pod.Namespace + "/Pod/" + pod.Name
+	}
+	return pod.Namespace + "/" + owner.Kind + "/" + owner.Name
+}
+
+// OwnerCount returns the number of distinct owners among the candidates
+func (s *CandidateSet) OwnerCount() int {
+	owners := map[string]bool{}
+	for _, pod := range s.Pods {
+		owners[s.OwnerKey(pod)] = true
+	}
+	return len(owners)
+}
+
+// KeepOwnerKind drops the candidates whose top-level owner is not of the given
+// kind. The owners must have been resolved.
+func (s *CandidateSet) KeepOwnerKind(kind string) {
+	var kept []v1.Pod
+	for _, pod := range s.Pods {
+		if s.Owners[pod.UID].Kind != kind {
+			s.Excluded["owner kind"]++
+			s.ByNamespace[pod.Namespace]--
+			continue
+		}
+		kept = append(kept, pod)
+	}
+	s.Pods = kept
+}
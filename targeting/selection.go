@@ -0,0 +1,183 @@
+package targeting
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Strategy decides the order in which candidates are picked
+type Strategy string
+
+const (
+	// StrategyRandom picks candidates uniformly at random
+	StrategyRandom Strategy = "random"
+	// StrategySpread picks one pod of every owner before a second pod of any owner
+	StrategySpread Strategy = "spread"
+)
+
+// Selection controls how victims are picked among the candidates
+type Selection struct {
+	Strategy Strategy
+	// MaxPerOwner caps the victims sharing a top-level owner; 0 is unlimited
+	MaxPerOwner int
+	// OwnerKind only accepts pods whose top-level owner has this kind; "" accepts any
+	OwnerKind string
+	// Weighting makes some candidates more likely to be picked than others
+	Weighting Weighting
+}
+
+// NeedsOwners reports whether the owners of the candidates have to be resolved
+func (s Selection) NeedsOwners() bool {
+	return s.Strategy == StrategySpread || s.MaxPerOwner > 0 || s.OwnerKind != ""
+}
+
+// Validate checks the selection settings
+func (s Selection) Validate() error {
+	switch s.Strategy {
+	case "", StrategyRandom, StrategySpread:
+	default:
+		return fmt.Errorf("unknown selection strategy %q, expected %s or %s", s.Strategy, StrategyRandom, StrategySpread)
+	}
+	if s.MaxPerOwner < 0 {
+		return fmt.Errorf("max per owner must not be negative, got %d", s.MaxPerOwner)
+	}
+	if s.OwnerKind != "" && !validOwnerKind(s.OwnerKind) {
+		return fmt.Errorf("unknown owner kind %q, expected one of %s", s.OwnerKind, strings.Join(OwnerKinds, ", "))
+	}
+	return nil
+}
+
+// validOwnerKind reports whether kind is one of OwnerKinds
+func validOwnerKind(kind string) bool {
+	for _, k := range OwnerKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Limit caps how many selected pods may share a key
+type Limit struct {
+	Key func(v1.Pod) string
+	// Max holds the cap of every key
+	Max map[string]int
+	// Default caps the keys missing from Max; 0 leaves them unlimited
+	Default int
+}
+
+// Select picks up to count candidates following the selection strategy,
+// without exceeding MaxPerOwner or any of the limits. All random choices are
+// drawn from rng, so the same seed and candidates give the same victims.
+func (s *CandidateSet) Select(rng *rand.Rand, count int, selection Selection, limits ...Limit) []v1.Pod {
+	order := shuffle(rng, s.Pods)
+	if selection.Weighting.By != "" {
+		order = weightedShuffle(rng, s.Pods, selection.Weighting.Weight)
+	}
+	if selection.Strategy == StrategySpread {
+		order = spread(order, s.OwnerKey)
+	}
+	if selection.MaxPerOwner > 0 {
+		limits = append(limits, Limit{Key: s.OwnerKey, Default: selection.MaxPerOwner})
+	}
+	return take(order, count, limits)
+}
+
+// shuffle returns the pods in random order
+func shuffle(rng *rand.Rand, pods []v1.Pod) []v1.Pod {
+	shuffled := make([]v1.Pod, 0, len(pods))
+	for _, i := range rng.Perm(len(pods)) {
+		shuffled = append(shuffled, pods[i])
+	}
+	return shuffled
+}
+
+// weightedShuffle returns the pods in random order, where a pod of weight w is
+// w times as likely to come first as a pod of weight 1. Pods of weight 0 come last.
+func weightedShuffle(rng *rand.Rand, pods []v1.Pod, weightOf func(v1.Pod) float64) []v1.Pod {
+	keys := make([]float64, len(pods))
+	for i, pod := range pods {
+		keys[i] = -1
+		if weight := weightOf(pod); weight > 0 {
+			keys[i] = math.Pow(rng.Float64(), 1/weight)
+		}
+	}
+
+	order := rng.Perm(len(pods))
+	sort.SliceStable(order, func(a, b int) bool { return keys[order[a]] > keys[order[b]] })
+	shuffled := make([]v1.Pod, 0, len(pods))
+	for _, i := range order {
+		shuffled = append(shuffled, pods[i])
+	}
+	return shuffled
+}
+
+// spread reorders the pods so that the owners take turns, keeping the order
+// of the pods of every owner and of the owners' first appearance
+func spread(pods []v1.Pod, keyOf func(v1.Pod) string) []v1.Pod {
+	var keys []string
+	groups := map[string][]v1.Pod{}
+	for _, pod := range pods {
+		key := keyOf(pod)
+		if _, seen := groups[key]; !seen {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], pod)
+	}
+
+	ordered := make([]v1.Pod, 0, len(pods))
+	for round := 0; len(ordered) < len(pods); round++ {
+		for _, key := range keys {
+			if round < len(groups[key]) {
+				ordered = append(ordered, groups[key][round])
+			}
+		}
+	}
+	return ordered
+}
+
+// take returns the first count pods that fit within the limits
+func take(pods []v1.Pod, count int, limits []Limit) []v1.Pod {
+	used := make([]map[string]int, len(limits))
+	for i := range limits {
+		used[i] = map[string]int{}
+	}
+
+	var selected []v1.Pod
+	for _, pod := range pods {
+		if len(selected) == count {
+			break
+		}
+		if !fits(pod, limits, used) {
+			continue
+		}
+		for i, limit := range limits {
+			used[i][limit.Key(pod)]++
+		}
+		selected = append(selected, pod)
+	}
+	return selected
+}
+
+// fits reports whether one more pod with the pod's keys stays within the limits
+func fits(pod v1.Pod, limits []Limit, used []map[string]int) bool {
+	for i, limit := range limits {
+		key := limit.Key(pod)
+		max, ok := limit.Max[key]
+		if !ok {
+			max = limit.Default
+			if max == 0 {
+				continue
+			}
+		}
+		if used[i][key] >= max {
+			return false
+		}
+	}
+	return true
+}
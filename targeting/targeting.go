@@ -0,0 +1,187 @@
+// Package targeting selects the pods that chaos is applied to.
+//
+// Every chaos type goes through FindCandidates so that listing, filtering
+// and exclusion rules behave the same way for all of them.
+package targeting
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ChaosTypeLabel is set on the helper pods kubechaos creates; such pods are never targeted
+const ChaosTypeLabel = "chaos-type"
+
+// Criteria describes which pods are eligible as chaos targets
+type Criteria struct {
+	Namespaces []string
+	// LabelSelector is passed to the API as is; nil selects every pod
+	LabelSelector labels.Selector
+	FieldSelector string
+
+	// Phases lists the accepted pod phases; when empty every phase except
+	// Succeeded and Failed is accepted
+	Phases []v1.PodPhase
+	// ReadyOnly only accepts pods whose Ready condition is true
+	ReadyOnly bool
+	// IncludeTerminating also accepts pods that already have a deletion timestamp
+	IncludeTerminating bool
+
+	// ExcludeLabels skips pods carrying any of these label keys, in addition to ChaosTypeLabel
+	ExcludeLabels []string
+	// ExcludePods skips pods by "namespace/name"
+	ExcludePods []string
+
+	// ChaosType skips pods whose AllowedChaosAnnotation does not list it
+	ChaosType string
+	// RequireOptIn only accepts pods annotated with EnabledAnnotation (strict mode)
+	RequireOptIn bool
+}
+
+// CandidateSet holds the pods that passed the selection criteria
+type CandidateSet struct {
+	Pods []v1.Pod
+	// Listed is the number of pods returned by the API before filtering
+	Listed int
+	// ByNamespace counts the candidates found in every searched namespace
+	ByNamespace map[string]int
+	// Excluded counts the filtered pods by reason
+	Excluded map[string]int
+	// Owners maps every candidate to its top-level owner, once resolved
+	Owners map[types.UID]Owner
+}
+
+// FindCandidates lists the pods in every namespace of the criteria and applies its filters
+func FindCandidates(ctx context.Context, client kubernetes.Interface, criteria Criteria) (*CandidateSet, error) {
+	if len(criteria.Namespaces) == 0 {
+		return nil, fmt.Errorf("no namespaces to select pods from")
+	}
+
+	excludedPods := make(map[string]bool, len(criteria.ExcludePods))
+	for _, name := range criteria.ExcludePods {
+		excludedPods[name] = true
+	}
+
+	listOptions := metav1.ListOptions{FieldSelector: criteria.FieldSelector}
+	if criteria.LabelSelector != nil {
+		listOptions.LabelSelector = criteria.LabelSelector.String()
+	}
+
+	set := &CandidateSet{ByNamespace: map[string]int{}, Excluded: map[string]int{}}
+	for _, namespace := range criteria.Namespaces {
+		pods, err := client.CoreV1().Pods(namespace).List(ctx, listOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods in namespace %s: %v", namespace, err)
+		}
+		set.Listed += len(pods.Items)
+		set.ByNamespace[namespace] = 0
+
+		for _, pod := range pods.Items {
+			reason := exclusionReason(pod, criteria, excludedPods)
+			if reason != "" {
+				set.Excluded[reason]++
+				continue
+			}
+			set.Pods = append(set.Pods, pod)
+			set.ByNamespace[namespace]++
+		}
+	}
+	return set, nil
+}
+
+// ExclusionReason returns why the pod does not pass the criteria's filtering and
+// exclusion rules, or "" if it does. It lets pods listed by other means, such as
+// the pods of a drained node, be fenced off the same way as candidates.
+func ExclusionReason(pod v1.Pod, criteria Criteria) string {
+	excludedPods := make(map[string]bool, len(criteria.ExcludePods))
+	for _, name := range criteria.ExcludePods {
+		excludedPods[name] = true
+	}
+	return exclusionReason(pod, criteria, excludedPods)
+}
+
+// exclusionReason returns why the pod is not a candidate, or "" if it is one
+func exclusionReason(pod v1.Pod, criteria Criteria, excludedPods map[string]bool) string {
+	if pod.Labels[ChaosTypeLabel] != "" {
+		return "chaos pod"
+	}
+	for _, key := range criteria.ExcludeLabels {
+		if _, ok := pod.Labels[key]; ok {
+			return "excluded label"
+		}
+	}
+	if excludedPods[pod.Namespace+"/"+pod.Name] {
+		return "excluded pod"
+	}
+	if OptedOut(pod.Annotations) {
+		return "opted out"
+	}
+	if criteria.RequireOptIn && !optedIn(pod.Annotations) {
+		return "not opted in"
+	}
+	if criteria.ChaosType != "" && !chaosAllowed(pod, criteria.ChaosType) {
+		return "chaos type not allowed"
+	}
+	if pod.DeletionTimestamp != nil && !criteria.IncludeTerminating {
+		return "terminating"
+	}
+	if !phaseAccepted(pod.Status.Phase, criteria.Phases) {
+		return "phase " + string(pod.Status.Phase)
+	}
+	if criteria.ReadyOnly && !IsPodReady(pod) {
+		return "not ready"
+	}
+	return ""
+}
+
+// phaseAccepted reports whether the phase is allowed by the accepted phases
+func phaseAccepted(phase v1.PodPhase, accepted []v1.PodPhase) bool {
+	if len(accepted) == 0 {
+		return phase != v1.PodSucceeded && phase != v1.PodFailed
+	}
+	for _, p := range accepted {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPodReady reports whether the pod's Ready condition is true
+func IsPodReady(pod v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// SelectRandom selects count random pods without duplicates
+func SelectRandom(rng *rand.Rand, pods []v1.Pod, count int) []v1.Pod {
+	if count >= len(pods) {
+		return pods
+	}
+
+	// Create a copy of the slice to avoid modifying the original
+	podCopy := make([]v1.Pod, len(pods))
+	copy(podCopy, pods)
+
+	selected := make([]v1.Pod, 0, count)
+	for i := 0; i < count; i++ {
+		// Pick a random index from remaining pods
+		randomIndex := rng.Intn(len(podCopy))
+		selected = append(selected, podCopy[randomIndex])
+
+		// Remove the selected pod from the copy
+		podCopy = append(podCopy[:randomIndex], podCopy[randomIndex+1:]...)
+	}
+	return selected
+}
@@ -0,0 +1,108 @@
+package targeting
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Weighting makes some candidates more likely to be picked than others
+type Weighting struct {
+	// By is "age", "restarts", "node", "annotation", or "" for uniform selection
+	By string
+	// Nodes weighs the pods by node name when By is "node"; other nodes weigh 1
+	Nodes map[string]float64
+	// Annotation holds the weight of a pod when By is "annotation"; pods without it weigh 1
+	Annotation string
+}
+
+// ParseWeighting parses a weighting: "age" (older pods weigh more), "restarts"
+// (pods that restarted more weigh more), "node:node-1=5,node-2=0.5" or
+// "annotation:<key>"
+func ParseWeighting(value string) (Weighting, error) {
+	by, arg, _ := strings.Cut(value, ":")
+	switch by {
+	case "":
+		return Weighting{}, nil
+	case "age", "restarts":
+		if arg != "" {
+			return Weighting{}, fmt.Errorf("invalid weighting %q: %s takes no argument", value, by)
+		}
+		return Weighting{By: by}, nil
+	case "node":
+		nodes := map[string]float64{}
+		for _, entry := range splitWeights(arg) {
+			node, weight, ok := strings.Cut(entry, "=")
+			w, err := strconv.ParseFloat(weight, 64)
+			if !ok || node == "" || err != nil || w < 0 {
+				return Weighting{}, fmt.Errorf("invalid weighting %q: expected node:<name>=<weight>,...", value)
+			}
+			nodes[node] = w
+		}
+		if len(nodes) == 0 {
+			return Weighting{}, fmt.Errorf("invalid weighting %q: expected node:<name>=<weight>,...", value)
+		}
+		return Weighting{By: by, Nodes: nodes}, nil
+	case "annotation":
+		if arg == "" {
+			return Weighting{}, fmt.Errorf("invalid weighting %q: expected annotation:<key>", value)
+		}
+		return Weighting{By: by, Annotation: arg}, nil
+	}
+	return Weighting{}, fmt.Errorf("invalid weighting %q: expected age, restarts, node:<name>=<weight>,... or annotation:<key>", value)
+}
+
+// splitWeights splits a comma-separated list, dropping empty entries
+func splitWeights(list string) []string {
+	var entries []string
+	for _, entry := range strings.Split(list, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func (w Weighting) String() string {
+	switch w.By {
+	case "node":
+		var nodes []string
+		for node, weight := range w.Nodes {
+			nodes = append(nodes, fmt.Sprintf("%s=%g", node, weight))
+		}
+		sort.Strings(nodes)
+		return "node:" + strings.Join(nodes, ",")
+	case "annotation":
+		return "annotation:" + w.Annotation
+	}
+	return w.By
+}
+
+// Weight returns the weight of a pod. Weights are relative: a pod of weight 2
+// is twice as likely to be picked as a pod of weight 1.
+func (w Weighting) Weight(pod v1.Pod) float64 {
+	switch w.By {
+	case "age":
+		// Minutes since the pod started, so fresh pods still have a chance
+		return time.Since(pod.CreationTimestamp.Time).Minutes() + 1
+	case "restarts":
+		restarts := 0
+		for _, status := range pod.Status.ContainerStatuses {
+			restarts += int(status.RestartCount)
+		}
+		return float64(restarts + 1)
+	case "node":
+		if weight, ok := w.Nodes[pod.Spec.NodeName]; ok {
+			return weight
+		}
+	case "annotation":
+		if weight, err := strconv.ParseFloat(pod.Annotations[w.Annotation], 64); err == nil && weight >= 0 {
+			return weight
+		}
+	}
+	return 1
+}
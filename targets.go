@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iamkrati22/kubechaos/targeting"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/kubernetes"
+)
+
+// namespaceCriteria returns the namespace selection of the configuration,
+// falling back to the single Namespace when no selection was configured
+func (c ChaosConfig) namespaceCriteria() targeting.NamespaceCriteria {
+	criteria := c.NamespaceSelection
+	if criteria.IsZero() {
+		criteria.Names = []string{c.Namespace}
+	}
+	return criteria
+}
+
+// namespaceScope describes the targeted namespaces for log output
+func (c ChaosConfig) namespaceScope() string {
+	return c.namespaceCriteria().String()
+}
+
+// resolveTargetNamespaces returns the namespaces the configuration targets
+func resolveTargetNamespaces(ctx context.Context, clientset *kubernetes.Clientset, config ChaosConfig) ([]string, error) {
+	namespaces, denied, err := targeting.ResolveNamespaces(ctx, clientset, config.namespaceCriteria())
+	if len(denied) > 0 {
+		fmt.Printf("🛡️  Skipping denied namespaces: %s\n", strings.Join(denied, ", "))
+	}
+	return namespaces, err
+}
+
+// forEachTargetNamespace calls fn for every namespace the configuration targets
+func forEachTargetNamespace(ctx context.Context, clientset *kubernetes.Clientset, config ChaosConfig, fn func(namespace string) error) error {
+	namespaces, err := resolveTargetNamespaces(ctx, clientset, config)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, namespace := range namespaces {
+		if err := fn(namespace); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", namespace, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// targetCriteria builds the target selection criteria for a chaos configuration
+func targetCriteria(namespaces []string, config ChaosConfig) targeting.Criteria {
+	return targeting.Criteria{
+		Namespaces:    namespaces,
+		LabelSelector: config.LabelSelector,
+		FieldSelector: config.FieldSelector,
+		ReadyOnly:     config.ReadyOnly,
+		ChaosType:     string(config.Type),
+		RequireOptIn:  config.Strict,
+	}
+}
+
+// selectTargets finds the eligible pods for the chaos configuration and picks
+// TargetCount of them following its selection strategy
+func selectTargets(ctx context.Context, clientset *kubernetes.Clientset, config ChaosConfig) ([]v1.Pod, error) {
+	candidates, err := findTargets(ctx, clientset, config)
+	if err != nil {
+		return nil, err
+	}
+	return candidates.Select(config.random(), targetCount(config, len(candidates.Pods)), config.Selection), nil
+}
+
+// targetCount computes the number of targets: TargetPercent of the available
+// pods (rounded up, within TargetMin and TargetMax) when set, otherwise
+// TargetCount. It is capped at the number of available pods.
+func targetCount(config ChaosConfig, available int) int {
+	count := config.TargetCount
+	if config.TargetPercent > 0 {
+		count = (available*config.TargetPercent + 99) / 100
+		if count < config.TargetMin {
+			count = config.TargetMin
+		}
+		if config.TargetMax > 0 && count > config.TargetMax {
+			count = config.TargetMax
+		}
+		fmt.Printf("🎯 Targeting %d%% of %d candidates: %d pods\n", config.TargetPercent, available, count)
+	}
+
+	if count > available {
+		fmt.Printf("⚠️  Requested %d target pods but only %d are available\n", count, available)
+		return available
+	}
+	return count
+}
+
+// findTargets returns every pod eligible as a target of the chaos configuration.
+// Their owners are resolved when the selection depends on them.
+func findTargets(ctx context.Context, clientset *kubernetes.Clientset, config ChaosConfig) (*targeting.CandidateSet, error) {
+	namespaces, err := resolveTargetNamespaces(ctx, clientset, config)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := targeting.FindCandidates(ctx, clientset, targetCriteria(namespaces, config))
+	if err != nil {
+		return nil, err
+	}
+	if config.Selection.NeedsOwners() {
+		if err := candidates.ResolveOwners(ctx, clientset); err != nil {
+			return nil, err
+		}
+		if config.Selection.OwnerKind != "" {
+			candidates.KeepOwnerKind(config.Selection.OwnerKind)
+		}
+		fmt.Printf("👥 %d candidates across %d owners\n", len(candidates.Pods), candidates.OwnerCount())
+	}
+	if config.Selection.Weighting.By != "" {
+		fmt.Printf("⚖️  Weighting candidates by %s\n", config.Selection.Weighting)
+	}
+	if len(namespaces) > 1 {
+		fmt.Printf("📊 Candidates by namespace: %s\n", describeNamespaceCounts(namespaces, candidates))
+	}
+
+	if len(candidates.Pods) == 0 {
+		if candidates.Listed == 0 {
+			return nil, fmt.Errorf("no pods found in namespace %s", config.namespaceScope())
+		}
+		return nil, fmt.Errorf("no available pods found in namespace %s (%s)", config.namespaceScope(), describeExclusions(candidates.Excluded))
+	}
+	return candidates, nil
+}
+
+// describeNamespaceCounts summarizes the candidates per namespace, e.g. "payments=3, orders=0"
+func describeNamespaceCounts(namespaces []string, candidates *targeting.CandidateSet) string {
+	var counts []string
+	for _, namespace := range namespaces {
+		counts = append(counts, fmt.Sprintf("%s=%d", namespace, candidates.ByNamespace[namespace]))
+	}
+	return strings.Join(counts, ", ")
+}
+
+// parseNamespaceSelection builds the namespace selection from the comma-separated
+// name/glob list, the namespace label selector and the extra deny-list
+func parseNamespaceSelection(names, selector, deny string) (targeting.NamespaceCriteria, error) {
+	criteria := targeting.NamespaceCriteria{
+		Names: splitList(names),
+		Deny:  splitList(deny),
+	}
+	if selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return criteria, fmt.Errorf("invalid namespace selector %q: %v", selector, err)
+		}
+		criteria.Selector = parsed
+	}
+	return criteria, nil
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// describeExclusions summarizes why candidates were filtered out, e.g. "excluded: 2 chaos pod, 1 terminating"
+func describeExclusions(excluded map[string]int) string {
+	var reasons []string
+	for reason, count := range excluded {
+		reasons = append(reasons, fmt.Sprintf("%d %s", count, reason))
+	}
+	sort.Strings(reasons)
+	return "excluded: " + strings.Join(reasons, ", ")
+}
+
+// parsePorts parses a comma-separated list of ports, e.g. "5432,6379"
+func parsePorts(list string) ([]int, error) {
+	var ports []int
+	for _, item := range splitList(list) {
+		port, err := strconv.Atoi(item)
+		if err != nil || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("invalid port %q", item)
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+// parseLabelSelector parses a label selector using the full Kubernetes grammar,
+// e.g. "app=nginx,env in (staging,qa),!canary,tier!=db"
+func parseLabelSelector(selector string) (labels.Selector, error) {
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %v", selector, err)
+	}
+	return parsed, nil
+}
+
+// selectorLabels returns the labels that a pod must carry to match the selector.
+// Only equality requirements (and set requirements with a single value) can be
+// turned into labels; the rest are returned as unsupported.
+func selectorLabels(selector labels.Selector) (map[string]string, []string) {
+	result := map[string]string{}
+	var unsupported []string
+	if selector == nil {
+		return result, nil
+	}
+
+	requirements, _ := selector.Requirements()
+	for _, requirement := range requirements {
+		values := requirement.Values().List()
+		switch requirement.Operator() {
+		case selection.Equals, selection.DoubleEquals, selection.In:
+			if len(values) == 1 {
+				result[requirement.Key()] = values[0]
+				continue
+			}
+		}
+		unsupported = append(unsupported, requirement.String())
+	}
+	return result, unsupported
+}
@@ -16,10 +16,11 @@ type TestPodConfig struct {
 	Count     int
 	Namespace string
 	Labels    map[string]string
+	Rand      *rand.Rand // Picks the test images
 }
 
 // CreateTestPods creates random test pods for chaos testing
-func CreateTestPods(clientset *kubernetes.Clientset, config TestPodConfig) error {
+func CreateTestPods(ctx context.Context, clientset *kubernetes.Clientset, config TestPodConfig) error {
 	testImages := []string{
 		"nginx:alpine",
 		"busybox:latest",
@@ -35,7 +36,7 @@ func CreateTestPods(clientset *kubernetes.Clientset, config TestPodConfig) error
 
 	for i := 1; i <= config.Count; i++ {
 		podName := fmt.Sprintf("test-pod-%d", i)
-		image := testImages[rand.Intn(len(testImages))]
+		image := testImages[config.Rand.Intn(len(testImages))]
 		
 		// Merge default labels with user-provided labels
 		labels := map[string]string{
@@ -68,7 +69,7 @@ func CreateTestPods(clientset *kubernetes.Clientset, config TestPodConfig) error
 			},
 		}
 
-		_, err := clientset.CoreV1().Pods(config.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+		_, err := clientset.CoreV1().Pods(config.Namespace).Create(ctx, pod, metav1.CreateOptions{})
 		if err != nil {
 			fmt.Printf("⚠️  Failed to create test pod %s: %v\n", podName, err)
 			return err
@@ -84,10 +85,10 @@ func CreateTestPods(clientset *kubernetes.Clientset, config TestPodConfig) error
 }
 
 // CleanupTestPods removes all test pods created by chaos monkey
-func CleanupTestPods(clientset *kubernetes.Clientset, namespace string) error {
+func CleanupTestPods(ctx context.Context, clientset *kubernetes.Clientset, namespace string) error {
 	fmt.Printf("🧹 Cleaning up test pods in namespace: %s\n", namespace)
 	
-	pods, err := clientset.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: "created=chaos-monkey",
 	})
 	if err != nil {
@@ -96,7 +97,7 @@ func CleanupTestPods(clientset *kubernetes.Clientset, namespace string) error {
 
 	for _, pod := range pods.Items {
 		fmt.Printf("🗑️  Deleting test pod: %s\n", pod.Name)
-		err := clientset.CoreV1().Pods(namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
+		err := clientset.CoreV1().Pods(namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
 		if err != nil {
 			fmt.Printf("⚠️  Failed to delete test pod %s: %v\n", pod.Name, err)
 		}
@@ -125,61 +125,61 @@ $passedTests = 0
 
 # Test 1: Help command
 $totalTests++
-$result = Test-Scenario -Name "Help Command" -Command "go run main.go test_pods.go -help" -Description "Test help command displays correctly"
+$result = Test-Scenario -Name "Help Command" -Command "go run . -help" -Description "Test help command displays correctly"
 if ($result) { $passedTests++ }
 $testResults += @{Name="Help Command"; Result=$result}
 
 # Test 2: Dry run mode
 $totalTests++
-$result = Test-Scenario -Name "Dry Run Mode" -Command "go run main.go test_pods.go -namespace=$Namespace -dry-run" -Description "Test dry-run shows what would be deleted without actually deleting"
+$result = Test-Scenario -Name "Dry Run Mode" -Command "go run . -namespace=$Namespace -dry-run" -Description "Test dry-run shows what would be deleted without actually deleting"
 if ($result) { $passedTests++ }
 $testResults += @{Name="Dry Run Mode"; Result=$result}
 
 # Test 3: Create test pods
 $totalTests++
-$result = Test-Scenario -Name "Create Test Pods" -Command "go run main.go test_pods.go -namespace=$Namespace -create -count=2" -Description "Test creating test pods"
+$result = Test-Scenario -Name "Create Test Pods" -Command "go run . -namespace=$Namespace -create -count=2" -Description "Test creating test pods"
 if ($result) { $passedTests++ }
 $testResults += @{Name="Create Test Pods"; Result=$result}
 
 # Test 4: Delete single pod
 $totalTests++
-$result = Test-Scenario -Name "Delete Single Pod" -Command "go run main.go test_pods.go -namespace=$Namespace -delete-count=1" -Description "Test deleting a single random pod"
+$result = Test-Scenario -Name "Delete Single Pod" -Command "go run . -namespace=$Namespace -delete-count=1" -Description "Test deleting a single random pod"
 if ($result) { $passedTests++ }
 $testResults += @{Name="Delete Single Pod"; Result=$result}
 
 # Test 5: Delete multiple pods
 $totalTests++
-$result = Test-Scenario -Name "Delete Multiple Pods" -Command "go run main.go test_pods.go -namespace=$Namespace -delete-count=2" -Description "Test deleting multiple random pods"
+$result = Test-Scenario -Name "Delete Multiple Pods" -Command "go run . -namespace=$Namespace -delete-count=2" -Description "Test deleting multiple random pods"
 if ($result) { $passedTests++ }
 $testResults += @{Name="Delete Multiple Pods"; Result=$result}
 
 # Test 6: Label filtering
 $totalTests++
-$result = Test-Scenario -Name "Label Filtering" -Command "go run main.go test_pods.go -namespace=$Namespace -labels='app=nginx' -dry-run" -Description "Test filtering pods by labels"
+$result = Test-Scenario -Name "Label Filtering" -Command "go run . -namespace=$Namespace -labels='app=nginx' -dry-run" -Description "Test filtering pods by labels"
 if ($result) { $passedTests++ }
 $testResults += @{Name="Label Filtering"; Result=$result}
 
 # Test 7: Cleanup test pods
 $totalTests++
-$result = Test-Scenario -Name "Cleanup Test Pods" -Command "go run main.go test_pods.go -namespace=$Namespace -cleanup" -Description "Test cleaning up test pods created by chaos monkey"
+$result = Test-Scenario -Name "Cleanup Test Pods" -Command "go run . -namespace=$Namespace -cleanup" -Description "Test cleaning up test pods created by chaos monkey"
 if ($result) { $passedTests++ }
 $testResults += @{Name="Cleanup Test Pods"; Result=$result}
 
 # Test 8: Invalid namespace
 $totalTests++
-$result = Test-Scenario -Name "Invalid Namespace" -Command "go run main.go test_pods.go -namespace=non-existent-namespace" -Description "Test behavior with non-existent namespace"
+$result = Test-Scenario -Name "Invalid Namespace" -Command "go run . -namespace=non-existent-namespace" -Description "Test behavior with non-existent namespace"
 if ($result) { $passedTests++ }
 $testResults += @{Name="Invalid Namespace"; Result=$result}
 
 # Test 9: No pods scenario
 $totalTests++
-$result = Test-Scenario -Name "No Pods Scenario" -Command "go run main.go test_pods.go -namespace=$Namespace -labels='app=nonexistent' -dry-run" -Description "Test behavior when no pods match criteria"
+$result = Test-Scenario -Name "No Pods Scenario" -Command "go run . -namespace=$Namespace -labels='app=nonexistent' -dry-run" -Description "Test behavior when no pods match criteria"
 if ($result) { $passedTests++ }
 $testResults += @{Name="No Pods Scenario"; Result=$result}
 
 # Test 10: Create and delete in one run
 $totalTests++
-$result = Test-Scenario -Name "Create and Delete" -Command "go run main.go test_pods.go -namespace=$Namespace -create -count=3 -delete-count=1" -Description "Test creating pods and then deleting one in the same run"
+$result = Test-Scenario -Name "Create and Delete" -Command "go run . -namespace=$Namespace -create -count=3 -delete-count=1" -Description "Test creating pods and then deleting one in the same run"
 if ($result) { $passedTests++ }
 $testResults += @{Name="Create and Delete"; Result=$result}
 
@@ -109,7 +109,7 @@ passed_tests=0
 
 # Test 1: Help command
 ((total_tests++))
-if test_scenario "Help Command" "go run main.go test_pods.go -help" "Test help command displays correctly"; then
+if test_scenario "Help Command" "go run . -help" "Test help command displays correctly"; then
     ((passed_tests++))
     test_results+=("Help Command: PASS")
 else
@@ -118,7 +118,7 @@ fi
 
 # Test 2: Dry run mode
 ((total_tests++))
-if test_scenario "Dry Run Mode" "go run main.go test_pods.go -namespace=$NAMESPACE -dry-run" "Test dry-run shows what would be deleted without actually deleting"; then
+if test_scenario "Dry Run Mode" "go run . -namespace=$NAMESPACE -dry-run" "Test dry-run shows what would be deleted without actually deleting"; then
     ((passed_tests++))
     test_results+=("Dry Run Mode: PASS")
 else
@@ -127,7 +127,7 @@ fi
 
 # Test 3: Create test pods
 ((total_tests++))
-if test_scenario "Create Test Pods" "go run main.go test_pods.go -namespace=$NAMESPACE -create -count=2" "Test creating test pods"; then
+if test_scenario "Create Test Pods" "go run . -namespace=$NAMESPACE -create -count=2" "Test creating test pods"; then
     ((passed_tests++))
     test_results+=("Create Test Pods: PASS")
 else
@@ -136,7 +136,7 @@ fi
 
 # Test 4: Delete single pod
 ((total_tests++))
-if test_scenario "Delete Single Pod" "go run main.go test_pods.go -namespace=$NAMESPACE -delete-count=1" "Test deleting a single random pod"; then
+if test_scenario "Delete Single Pod" "go run . -namespace=$NAMESPACE -delete-count=1" "Test deleting a single random pod"; then
     ((passed_tests++))
     test_results+=("Delete Single Pod: PASS")
 else
@@ -145,7 +145,7 @@ fi
 
 # Test 5: Delete multiple pods
 ((total_tests++))
-if test_scenario "Delete Multiple Pods" "go run main.go test_pods.go -namespace=$NAMESPACE -delete-count=2" "Test deleting multiple random pods"; then
+if test_scenario "Delete Multiple Pods" "go run . -namespace=$NAMESPACE -delete-count=2" "Test deleting multiple random pods"; then
     ((passed_tests++))
     test_results+=("Delete Multiple Pods: PASS")
 else
@@ -154,7 +154,7 @@ fi
 
 # Test 6: Label filtering
 ((total_tests++))
-if test_scenario "Label Filtering" "go run main.go test_pods.go -namespace=$NAMESPACE -labels='app=nginx' -dry-run" "Test filtering pods by labels"; then
+if test_scenario "Label Filtering" "go run . -namespace=$NAMESPACE -labels='app=nginx' -dry-run" "Test filtering pods by labels"; then
     ((passed_tests++))
     test_results+=("Label Filtering: PASS")
 else
@@ -163,7 +163,7 @@ fi
 
 # Test 7: Cleanup test pods
 ((total_tests++))
-if test_scenario "Cleanup Test Pods" "go run main.go test_pods.go -namespace=$NAMESPACE -cleanup" "Test cleaning up test pods created by chaos monkey"; then
+if test_scenario "Cleanup Test Pods" "go run . -namespace=$NAMESPACE -cleanup" "Test cleaning up test pods created by chaos monkey"; then
     ((passed_tests++))
     test_results+=("Cleanup Test Pods: PASS")
 else
@@ -172,7 +172,7 @@ fi
 
 # Test 8: Invalid namespace
 ((total_tests++))
-if test_scenario "Invalid Namespace" "go run main.go test_pods.go -namespace=non-existent-namespace" "Test behavior with non-existent namespace"; then
+if test_scenario "Invalid Namespace" "go run . -namespace=non-existent-namespace" "Test behavior with non-existent namespace"; then
     ((passed_tests++))
     test_results+=("Invalid Namespace: PASS")
 else
@@ -181,7 +181,7 @@ fi
 
 # Test 9: No pods scenario
 ((total_tests++))
-if test_scenario "No Pods Scenario" "go run main.go test_pods.go -namespace=$NAMESPACE -labels='app=nonexistent' -dry-run" "Test behavior when no pods match criteria"; then
+if test_scenario "No Pods Scenario" "go run . -namespace=$NAMESPACE -labels='app=nonexistent' -dry-run" "Test behavior when no pods match criteria"; then
     ((passed_tests++))
     test_results+=("No Pods Scenario: PASS")
 else
@@ -190,7 +190,7 @@ fi
 
 # Test 10: Create and delete in one run
 ((total_tests++))
-if test_scenario "Create and Delete" "go run main.go test_pods.go -namespace=$NAMESPACE -create -count=3 -delete-count=1" "Test creating pods and then deleting one in the same run"; then
+if test_scenario "Create and Delete" "go run . -namespace=$NAMESPACE -create -count=3 -delete-count=1" "Test creating pods and then deleting one in the same run"; then
     ((passed_tests++))
     test_results+=("Create and Delete: PASS")
 else
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+)
+
+// timeSkewAnnotation marks pods whose clock is currently shifted by kubechaos
+// and holds the name of the ephemeral helper container doing it
+const timeSkewAnnotation = "kubechaos.io/time-skew"
+
+// timeSkewPidFile holds the PID of the helper script inside the ephemeral container
+const timeSkewPidFile = "/tmp/.kubechaos-time.pid"
+
+// timeSkewStartTimeout bounds how long the ephemeral helper container may take to start
+const timeSkewStartTimeout = 2 * time.Minute
+
+func init() {
+	RegisterInjector(&funcInjector{
+		name:     ChaosTypeTimeSkew,
+		validate: validateTimeSkew,
+		inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return ApplyTimeSkewChaos(ctx, env.RestConfig, env.Clientset, config)
+		},
+		revert: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+			return forEachTargetNamespace(ctx, env.Clientset, config, func(namespace string) error {
+				return CleanupTimeSkew(ctx, env.RestConfig, env.Clientset, namespace, config.RunID)
+			})
+		},
+	}, "Shift the wall clock of the target containers (ephemeral helper container)")
+}
+
+// validateTimeSkew checks the settings specific to time-skew chaos
+func validateTimeSkew(config ChaosConfig) error {
+	if config.Time.Offset == 0 {
+		return fmt.Errorf("time offset must not be zero")
+	}
+	if config.Time.HelperImage == "" {
+		return fmt.Errorf("time skew needs a helper image")
+	}
+	return nil
+}
+
+// generateTimeSkewCommand creates the script run by the ephemeral helper container.
+// The helper shares the PID namespace of the target container. The application is
+// not always PID 1 (shell entrypoints, tini, worker processes), so the script
+// shifts the CLOCK_REALTIME of every process found in /proc when it starts, except
+// its own shell, with watchmaker. It fails only when no process could be shifted.
+// The script restores the clocks itself when the duration ends or when it receives
+// SIGTERM, so the time is put back even if kubechaos dies.
+func generateTimeSkewCommand(offset, duration time.Duration) string {
+	return fmt.Sprintf("echo $$ > %s; ", timeSkewPidFile) +
+		"pids=; for d in /proc/[0-9]*; do p=${d#/proc/}; [ \"$p\" = \"$$\" ] || pids=\"$pids $p\"; done; " +
+		"skew() { ok=1; for p in $pids; do " +
+		"watchmaker -pid $p -sec_delta $1 -nsec_delta $2 -clk_ids CLOCK_REALTIME >/dev/null 2>&1 && ok=0 && echo \"shifted pid $p by $1s\"; " +
+		"done; return $ok; }; " +
+		"trap 'skew 0 0; exit 0' TERM INT; " +
+		fmt.Sprintf("skew %d %d || exit 1; ", int64(offset/time.Second), int64(offset%time.Second)) +
+		fmt.Sprintf("sleep %d & wait $!; ", int(duration.Seconds())) +
+		"skew 0 0"
+}
+
+// ApplyTimeSkewChaos shifts the clock of the main container of the selected
+// pods by the configured offset for the configured duration
+func ApplyTimeSkewChaos(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, chaosConfig ChaosConfig) error {
+	fmt.Printf("🕰️  Applying TIME SKEW chaos to namespace: %s (offset: %s)\n", chaosConfig.namespaceScope(), chaosConfig.Time.Offset)
+
+	selectedPods, err := selectTargets(ctx, clientset, chaosConfig)
+	if err != nil {
+		return err
+	}
+
+	cmd := generateTimeSkewCommand(chaosConfig.Time.Offset, chaosConfig.Duration)
+	if chaosConfig.DryRun {
+		fmt.Println("🔍 DRY RUN MODE - No clock will be shifted")
+		fmt.Printf("📋 Would shift the clock of %d pods by %s:\n", len(selectedPods), chaosConfig.Time.Offset)
+		for i, pod := range selectedPods {
+			fmt.Printf("  %d. %s/%s\n", i+1, pod.Namespace, pod.Name)
+		}
+		fmt.Printf("📋 Command: %s\n", cmd)
+		return nil
+	}
+
+	var skewedPods []v1.Pod
+	for i, pod := range selectedPods {
+		if ctx.Err() != nil {
+			break
+		}
+		if len(pod.Spec.Containers) == 0 {
+			fmt.Printf("⚠️  Pod %s has no containers, skipping\n", pod.Name)
+			continue
+		}
+		if _, busy := pod.Annotations[timeSkewAnnotation]; busy {
+			fmt.Printf("⚠️  Pod %s already has its clock shifted, skipping\n", pod.Name)
+			continue
+		}
+		target := pod.Spec.Containers[0].Name
+		helper := fmt.Sprintf("kubechaos-time-%d", time.Now().Unix())
+
+		fmt.Printf("🕰️  Shifting clock of pod %d/%d: %s (container: %s, helper: %s)\n", i+1, len(selectedPods), pod.Name, target, helper)
+		fmt.Printf("📋 Command: %s\n", cmd)
+
+		// Record the fault before injecting it so -cleanup can always find it
+		if err := markPod(ctx, clientset, pod.Namespace, pod.Name, timeSkewAnnotation, helper, chaosConfig.RunID); err != nil {
+			fmt.Printf("❌ Failed to annotate pod %s: %v\n", pod.Name, err)
+			continue
+		}
+		if err := addTimeSkewHelper(ctx, clientset, pod.Namespace, pod.Name, target, helper, chaosConfig.Time.HelperImage, cmd); err != nil {
+			fmt.Printf("❌ Failed to shift clock of pod %s: %v\n", pod.Name, err)
+			revertTimeSkew(ctx, config, clientset, pod.Namespace, pod.Name)
+			continue
+		}
+		fmt.Printf("✅ Successfully shifted clock of pod: %s\n", pod.Name)
+		skewedPods = append(skewedPods, pod)
+	}
+
+	if len(skewedPods) == 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to shift the clock of any pod")
+	}
+
+	fmt.Printf("⏳ Holding time skew for %s...\n", chaosConfig.Duration)
+	if holdChaos(ctx, chaosConfig.Duration) != nil {
+		// The markers stay on the pods so the rollback can restore the clocks
+		fmt.Println("🛑 Time skew interrupted")
+		return ctx.Err()
+	}
+
+	for _, pod := range skewedPods {
+		revertTimeSkew(ctx, config, clientset, pod.Namespace, pod.Name)
+	}
+	return nil
+}
+
+// addTimeSkewHelper adds the ephemeral helper container to the pod and waits until it runs
+func addTimeSkewHelper(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName, target, helper, image, cmd string) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, v1.EphemeralContainer{
+			EphemeralContainerCommon: v1.EphemeralContainerCommon{
+				Name:    helper,
+				Image:   image,
+				Command: []string{"sh", "-c", cmd},
+				SecurityContext: &v1.SecurityContext{
+					Capabilities: &v1.Capabilities{Add: []v1.Capability{"SYS_PTRACE"}},
+				},
+			},
+			TargetContainerName: target,
+		})
+		_, err = clientset.CoreV1().Pods(namespace).UpdateEphemeralContainers(ctx, podName, pod, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add ephemeral container: %v", err)
+	}
+
+	deadline := time.Now().Add(timeSkewStartTimeout)
+	for time.Now().Before(deadline) {
+		status, err := ephemeralContainerStatus(ctx, clientset, namespace, podName, helper)
+		if err != nil {
+			return err
+		}
+		switch {
+		case status == nil:
+		case status.State.Running != nil:
+			return nil
+		case status.State.Terminated != nil:
+			return fmt.Errorf("helper container exited with code %d (%s)", status.State.Terminated.ExitCode, status.State.Terminated.Reason)
+		case status.State.Waiting != nil && isImagePullFailure(status.State.Waiting.Reason):
+			return fmt.Errorf("helper image %s cannot be pulled: %s", image, status.State.Waiting.Message)
+		}
+		if err := holdChaos(ctx, 2*time.Second); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("helper container did not start within %s", timeSkewStartTimeout)
+}
+
+// isImagePullFailure reports whether a waiting reason means the image will not be pulled
+func isImagePullFailure(reason string) bool {
+	return reason == "ErrImagePull" || reason == "ImagePullBackOff" || reason == "InvalidImageName"
+}
+
+// ephemeralContainerStatus returns the status of the named ephemeral container, or nil if it has none yet
+func ephemeralContainerStatus(ctx context.Context, clientset *kubernetes.Clientset, namespace, podName, name string) (*v1.ContainerStatus, error) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pod.Status.EphemeralContainerStatuses {
+		if pod.Status.EphemeralContainerStatuses[i].Name == name {
+			return &pod.Status.EphemeralContainerStatuses[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// revertTimeSkew stops the helper container of the pod, which restores the clock,
+// and clears its marker annotation. Ephemeral containers cannot be removed from a
+// pod, so the exited helper stays listed in its spec.
+func revertTimeSkew(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, podName string) error {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	helper, ok := pod.Annotations[timeSkewAnnotation]
+	if !ok {
+		return nil
+	}
+
+	fmt.Printf("🔧 Restoring clock of pod: %s (helper: %s)\n", podName, helper)
+	status, err := ephemeralContainerStatus(ctx, clientset, namespace, podName, helper)
+	if err != nil {
+		return err
+	}
+	if status != nil && status.State.Terminated == nil {
+		stop := fmt.Sprintf("kill -TERM $(cat %s)", timeSkewPidFile)
+		if err := execInPod(ctx, config, clientset, namespace, podName, helper, stop); err != nil {
+			fmt.Printf("❌ Failed to stop time helper in pod %s: %v\n", podName, err)
+			return err
+		}
+		for i := 0; i < 15 && status != nil && status.State.Terminated == nil; i++ {
+			if err := holdChaos(ctx, 2*time.Second); err != nil {
+				return err
+			}
+			if status, err = ephemeralContainerStatus(ctx, clientset, namespace, podName, helper); err != nil {
+				return err
+			}
+		}
+		if status != nil && status.State.Terminated == nil {
+			return fmt.Errorf("time helper in pod %s is still running", podName)
+		}
+	}
+	if status != nil && status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
+		fmt.Printf("⚠️  Time helper in pod %s exited with code %d, check its clock\n", podName, status.State.Terminated.ExitCode)
+	}
+
+	if err := patchPodAnnotation(ctx, clientset, namespace, podName, timeSkewAnnotation, nil); err != nil {
+		fmt.Printf("⚠️  Failed to remove time-skew annotation from pod %s: %v\n", podName, err)
+		return err
+	}
+	return nil
+}
+
+// CleanupTimeSkew restores the clock of the pods the run left shifted in the
+// namespace, or of those of any run when runID is empty
+func CleanupTimeSkew(ctx context.Context, config *rest.Config, clientset *kubernetes.Clientset, namespace, runID string) error {
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %v", err)
+	}
+
+	cleaned := 0
+	var failed []string
+	for _, pod := range pods.Items {
+		if _, ok := pod.Annotations[timeSkewAnnotation]; !ok || !ownedByRun(pod.Annotations, timeSkewAnnotation, runID) {
+			continue
+		}
+		if err := revertTimeSkew(ctx, config, clientset, namespace, pod.Name); err != nil {
+			failed = append(failed, pod.Name)
+			continue
+		}
+		cleaned++
+	}
+	if cleaned > 0 {
+		fmt.Printf("✅ Restored the clock of %d pods\n", cleaned)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to restore the clock of pods %v", failed)
+	}
+	return nil
+}
@@ -0,0 +1,370 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// originalReplicasAnnotation is set on workloads scaled by kubechaos and holds
+// the JSON encoded scaleMarker needed to restore them
+const originalReplicasAnnotation = "kubechaos.io/original-replicas"
+
+// scaleMarker is stored in the originalReplicasAnnotation of a scaled workload
+type scaleMarker struct {
+	Replicas int32     `json:"replicas"`
+	Until    time.Time `json:"until"` // End of the fault; later runs restore expired markers
+}
+
+// workloadRef identifies a Deployment or StatefulSet
+type workloadRef struct {
+	Namespace string
+	Kind      string
+	Name      string
+}
+
+func (w workloadRef) String() string {
+	return w.Namespace + "/" + w.Kind + "/" + w.Name
+}
+
+func init() {
+	for chaosType, description := range map[ChaosType]string{
+		ChaosTypeScaleToZero: "Scale Deployments/StatefulSets to zero replicas, then restore them",
+		ChaosTypeScaleDown:   "Remove a percentage of the replicas of Deployments/StatefulSets, then restore them",
+	} {
+		RegisterInjector(&funcInjector{
+			name:     chaosType,
+			validate: validateWorkloadScale,
+			inject: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+				return ApplyWorkloadScaleChaos(ctx, env.Clientset, config)
+			},
+			revert: func(ctx context.Context, env ChaosEnv, config ChaosConfig) error {
+				return forEachTargetNamespace(ctx, env.Clientset, config, func(namespace string) error {
+					return RestoreScaledWorkloads(ctx, env.Clientset, namespace, config.RunID, false)
+				})
+			},
+		}, description)
+	}
+}
+
+// validateWorkloadScale checks the settings specific to the workload scale chaos types
+func validateWorkloadScale(config ChaosConfig) error {
+	if config.Type == ChaosTypeScaleDown && (config.Workload.ScalePercent < 1 || config.Workload.ScalePercent > 100) {
+		return fmt.Errorf("scale percent must be between 1 and 100, got %d", config.Workload.ScalePercent)
+	}
+	return nil
+}
+
+// scaledReplicas returns the replica count a workload is scaled to: zero for
+// scale-to-zero, otherwise the count without ScalePercent of the replicas (rounded up)
+func scaledReplicas(config ChaosConfig, replicas int32) int32 {
+	if config.Type == ChaosTypeScaleToZero {
+		return 0
+	}
+	removed := (replicas*int32(config.Workload.ScalePercent) + 99) / 100
+	return replicas - removed
+}
+
+// getScale reads the scale subresource of the workload
+func getScale(ctx context.Context, clientset *kubernetes.Clientset, workload workloadRef) (*autoscalingv1.Scale, error) {
+	if workload.Kind == "StatefulSet" {
+		return clientset.AppsV1().StatefulSets(workload.Namespace).GetScale(ctx, workload.Name, metav1.GetOptions{})
+	}
+	return clientset.AppsV1().Deployments(workload.Namespace).GetScale(ctx, workload.Name, metav1.GetOptions{})
+}
+
+// updateScale writes the scale subresource of the workload
+func updateScale(ctx context.Context, clientset *kubernetes.Clientset, workload workloadRef, scale *autoscalingv1.Scale) error {
+	var err error
+	if workload.Kind == "StatefulSet" {
+		_, err = clientset.AppsV1().StatefulSets(workload.Namespace).UpdateScale(ctx, workload.Name, scale, metav1.UpdateOptions{})
+	} else {
+		_, err = clientset.AppsV1().Deployments(workload.Namespace).UpdateScale(ctx, workload.Name, scale, metav1.UpdateOptions{})
+	}
+	return err
+}
+
+// workloadAnnotations returns the annotations of the workload
+func workloadAnnotations(ctx context.Context, clientset *kubernetes.Clientset, workload workloadRef) (map[string]string, error) {
+	if workload.Kind == "StatefulSet" {
+		statefulSet, err := clientset.AppsV1().StatefulSets(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return statefulSet.Annotations, nil
+	}
+	deployment, err := clientset.AppsV1().Deployments(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return deployment.Annotations, nil
+}
+
+// setScaleMarker stores the marker and run ID in the workload annotations, or
+// removes them when marker is nil
+func setScaleMarker(ctx context.Context, clientset *kubernetes.Clientset, workload workloadRef, marker *scaleMarker, runID string) error {
+	var value, run interface{}
+	if marker != nil {
+		encoded, err := json.Marshal(marker)
+		if err != nil {
+			return err
+		}
+		value = string(encoded)
+		if runID != "" {
+			run = runID
+		}
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				originalReplicasAnnotation:                value,
+				runAnnotation(originalReplicasAnnotation): run,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if workload.Kind == "StatefulSet" {
+		_, err = clientset.AppsV1().StatefulSets(workload.Namespace).Patch(ctx, workload.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	} else {
+		_, err = clientset.AppsV1().Deployments(workload.Namespace).Patch(ctx, workload.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	}
+	return err
+}
+
+// selectWorkloads picks TargetCount of the Deployments and StatefulSets owning
+// the candidate pods, so pod exclusions and opt-outs apply to workloads too
+func selectWorkloads(ctx context.Context, clientset *kubernetes.Clientset, config ChaosConfig) ([]workloadRef, error) {
+	candidates, err := findTargets(ctx, clientset, config)
+	if err != nil {
+		return nil, err
+	}
+	if candidates.Owners == nil {
+		if err := candidates.ResolveOwners(ctx, clientset); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := map[workloadRef]bool{}
+	var workloads []workloadRef
+	for _, pod := range candidates.Pods {
+		owner := candidates.Owners[pod.UID]
+		if owner.Kind != "Deployment" && owner.Kind != "StatefulSet" {
+			continue
+		}
+		workload := workloadRef{Namespace: pod.Namespace, Kind: owner.Kind, Name: owner.Name}
+		if !seen[workload] {
+			seen[workload] = true
+			workloads = append(workloads, workload)
+		}
+	}
+	if len(workloads) == 0 {
+		return nil, fmt.Errorf("no Deployments or StatefulSets own the candidate pods in namespace %s", config.namespaceScope())
+	}
+
+	// Sort before shuffling so a seed replays the same choice
+	sort.Slice(workloads, func(i, j int) bool { return workloads[i].String() < workloads[j].String() })
+	rng := config.random()
+	rng.Shuffle(len(workloads), func(i, j int) { workloads[i], workloads[j] = workloads[j], workloads[i] })
+	return workloads[:targetCount(config, len(workloads))], nil
+}
+
+// ApplyWorkloadScaleChaos scales the selected workloads down for the configured
+// duration through their scale subresource, then restores their replica count
+func ApplyWorkloadScaleChaos(ctx context.Context, clientset *kubernetes.Clientset, config ChaosConfig) error {
+	fmt.Printf("📉 Applying %s chaos to namespace: %s\n", config.Type, config.namespaceScope())
+
+	// Restore workloads left scaled by a run that did not get to restore them; a
+	// dry run changes nothing and reports them as already scaled down instead
+	if !config.DryRun {
+		if err := forEachTargetNamespace(ctx, clientset, config, func(namespace string) error {
+			return RestoreScaledWorkloads(ctx, clientset, namespace, "", true)
+		}); err != nil {
+			return err
+		}
+	}
+
+	workloads, err := selectWorkloads(ctx, clientset, config)
+	if err != nil {
+		return err
+	}
+
+	if config.DryRun {
+		fmt.Println("🔍 DRY RUN MODE - No workload will be scaled")
+		fmt.Printf("📋 Would scale %d workloads:\n", len(workloads))
+	}
+	var scaled []workloadRef
+	for i, workload := range workloads {
+		if ctx.Err() != nil {
+			break
+		}
+		annotations, err := workloadAnnotations(ctx, clientset, workload)
+		if err != nil {
+			fmt.Printf("❌ Failed to read %s: %v\n", workload, err)
+			continue
+		}
+		if _, busy := annotations[originalReplicasAnnotation]; busy {
+			fmt.Printf("⚠️  %s is already scaled down by kubechaos, skipping\n", workload)
+			continue
+		}
+		scale, err := getScale(ctx, clientset, workload)
+		if err != nil {
+			fmt.Printf("❌ Failed to read the scale of %s: %v\n", workload, err)
+			continue
+		}
+		original := scale.Spec.Replicas
+		replicas := scaledReplicas(config, original)
+		if replicas == original {
+			fmt.Printf("⚠️  %s already runs %d replicas, skipping\n", workload, original)
+			continue
+		}
+
+		if config.DryRun {
+			fmt.Printf("  %d. %s (%d → %d replicas)\n", i+1, workload, original, replicas)
+			continue
+		}
+		fmt.Printf("📉 Scaling workload %d/%d: %s (%d → %d replicas)\n", i+1, len(workloads), workload, original, replicas)
+
+		// Record the original count before scaling so it can always be restored
+		if err := setScaleMarker(ctx, clientset, workload, &scaleMarker{Replicas: original, Until: time.Now().Add(config.Duration)}, config.RunID); err != nil {
+			fmt.Printf("❌ Failed to annotate %s: %v\n", workload, err)
+			continue
+		}
+		scale.Spec.Replicas = replicas
+		if err := updateScale(ctx, clientset, workload, scale); err != nil {
+			fmt.Printf("❌ Failed to scale %s: %v\n", workload, err)
+			setScaleMarker(ctx, clientset, workload, nil, "")
+			continue
+		}
+		fmt.Printf("✅ Scaled %s to %d replicas\n", workload, replicas)
+		scaled = append(scaled, workload)
+	}
+
+	if config.DryRun {
+		return nil
+	}
+	if len(scaled) == 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to scale any workload")
+	}
+
+	fmt.Printf("⏳ Holding %s for %s...\n", config.Type, config.Duration)
+	if holdChaos(ctx, config.Duration) != nil {
+		// The annotations stay on the workloads so the rollback can restore them
+		fmt.Printf("🛑 %s interrupted\n", config.Type)
+		return ctx.Err()
+	}
+
+	var failed []string
+	for _, workload := range scaled {
+		if err := restoreWorkload(ctx, clientset, workload); err != nil {
+			failed = append(failed, workload.String())
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to restore workloads %v", failed)
+	}
+	return nil
+}
+
+// restoreWorkload scales the workload back to the replica count in its annotation and removes the annotation
+func restoreWorkload(ctx context.Context, clientset *kubernetes.Clientset, workload workloadRef) error {
+	scale, err := getScale(ctx, clientset, workload)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	annotations, err := workloadAnnotations(ctx, clientset, workload)
+	if err != nil {
+		return err
+	}
+	value, ok := annotations[originalReplicasAnnotation]
+	if !ok {
+		return nil
+	}
+	var marker scaleMarker
+	if err := json.Unmarshal([]byte(value), &marker); err != nil {
+		fmt.Printf("⚠️  Ignoring malformed %s annotation on %s: %v\n", originalReplicasAnnotation, workload, err)
+		return err
+	}
+
+	fmt.Printf("🔧 Restoring %s to %d replicas\n", workload, marker.Replicas)
+	scale.Spec.Replicas = marker.Replicas
+	if err := updateScale(ctx, clientset, workload, scale); err != nil {
+		fmt.Printf("❌ Failed to restore %s: %v\n", workload, err)
+		return err
+	}
+	if err := setScaleMarker(ctx, clientset, workload, nil, ""); err != nil {
+		fmt.Printf("⚠️  Failed to remove the %s annotation from %s: %v\n", originalReplicasAnnotation, workload, err)
+		return err
+	}
+	return nil
+}
+
+// RestoreScaledWorkloads restores the Deployments and StatefulSets that the run
+// scaled down in the namespace, or that any run did when runID is empty. With
+// expiredOnly, only workloads whose fault should already have ended are restored.
+func RestoreScaledWorkloads(ctx context.Context, clientset *kubernetes.Clientset, namespace, runID string, expiredOnly bool) error {
+	var workloads []workloadRef
+	markers := map[workloadRef]string{}
+	deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list deployments: %v", err)
+	}
+	for _, deployment := range deployments.Items {
+		if value, ok := deployment.Annotations[originalReplicasAnnotation]; ok && ownedByRun(deployment.Annotations, originalReplicasAnnotation, runID) {
+			workload := workloadRef{Namespace: namespace, Kind: "Deployment", Name: deployment.Name}
+			workloads = append(workloads, workload)
+			markers[workload] = value
+		}
+	}
+	statefulSets, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list statefulsets: %v", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if value, ok := statefulSet.Annotations[originalReplicasAnnotation]; ok && ownedByRun(statefulSet.Annotations, originalReplicasAnnotation, runID) {
+			workload := workloadRef{Namespace: namespace, Kind: "StatefulSet", Name: statefulSet.Name}
+			workloads = append(workloads, workload)
+			markers[workload] = value
+		}
+	}
+
+	restored := 0
+	var failed []string
+	for _, workload := range workloads {
+		if expiredOnly {
+			var marker scaleMarker
+			if json.Unmarshal([]byte(markers[workload]), &marker) == nil && time.Now().Before(marker.Until) {
+				// Another run may still be holding this fault
+				continue
+			}
+		}
+		if err := restoreWorkload(ctx, clientset, workload); err != nil {
+			failed = append(failed, workload.String())
+			continue
+		}
+		restored++
+	}
+	if restored > 0 {
+		fmt.Printf("✅ Restored the replicas of %d workloads\n", restored)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to restore workloads %v", failed)
+	}
+	return nil
+}